@@ -0,0 +1,187 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/Bharath-code/git-scope/internal/gitops"
+	"github.com/Bharath-code/git-scope/internal/model"
+	"github.com/Bharath-code/git-scope/internal/scan"
+)
+
+// runExec runs a command in every selected repo in parallel, then prints a
+// pass/fail table and exits 1 if the command failed anywhere
+func runExec(args []string, opts options) error {
+	fs := flag.NewFlagSet("exec", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, `Usage: git-scope exec [flags] [directories...] -- <command> [args...]
+
+Runs the command in every repo (or the repos selected by the filter
+flags), with the repo as working directory. A single argument is run by
+the shell, so pipes work: git-scope exec -- 'git log -1 | cat'.
+GIT_SCOPE_REPO and GIT_SCOPE_PATH are set to the repo name and path.
+
+Flags:
+`)
+		fs.PrintDefaults()
+	}
+	jobs := fs.Int("j", gitops.DefaultConcurrency, "Number of repos to run the command in at once")
+	group := fs.Bool("group", false, "Print each repo's output as one block when it finishes, instead of prefixed lines")
+	timeout := fs.Duration("timeout", 0, "Kill the command in a repo after this long (0 = no limit)")
+	var filters filterFlags
+	filters.register(fs)
+
+	flagArgs, argv := splitCommand(args)
+	if err := fs.Parse(flagArgs); err != nil {
+		if err == flag.ErrHelp {
+			return nil
+		}
+		return &exitError{code: 2}
+	}
+	if len(argv) == 0 {
+		fs.Usage()
+		return &exitError{code: 2, err: fmt.Errorf("no command given after --")}
+	}
+	if err := filters.parse(); err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig(opts.ConfigPath, fs.Args())
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	repos, err := scan.ScanRootsContext(ctx, cfg.Roots, cfg.Ignore, scan.OptionsFromConfig(cfg))
+	if err != nil {
+		return fmt.Errorf("scan error: %w", err)
+	}
+	repos = filters.apply(repos)
+	if len(repos) == 0 {
+		fmt.Println("No repos to run in")
+		return nil
+	}
+
+	var mu sync.Mutex
+	nameWidth := longestName(repos)
+	newOutput := func(repo model.Repo) io.WriteCloser {
+		if *group {
+			return &groupWriter{mu: &mu, w: os.Stdout, header: fmt.Sprintf("── %s (%s)\n", repo.Name, displayPath(repo.Path))}
+		}
+		return &prefixWriter{mu: &mu, w: os.Stdout, prefix: fmt.Sprintf("%-*s │ ", nameWidth, repo.Name)}
+	}
+
+	timeoutOpt := *timeout
+	if timeoutOpt <= 0 {
+		timeoutOpt = gitops.NoTimeout
+	}
+	results := make(map[string]gitops.Result, len(repos))
+	op := gitops.CommandOp(argv, newOutput)
+	for res := range gitops.Run(ctx, repos, op, gitops.Options{Concurrency: *jobs, Timeout: timeoutOpt}) {
+		results[res.Repo.Path] = res
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	failed := 0
+	fmt.Println()
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, r := range repos {
+		res := results[r.Path]
+		if res.Err != nil {
+			failed++
+			fmt.Fprintf(tw, "✗ fail\t%s\t%.1fs\t%v\n", r.Name, res.Elapsed.Seconds(), res.Err)
+		} else {
+			fmt.Fprintf(tw, "✓ pass\t%s\t%.1fs\t\n", r.Name, res.Elapsed.Seconds())
+		}
+	}
+	tw.Flush()
+
+	fmt.Printf("\n%d passed, %d failed\n", len(repos)-failed, failed)
+	if failed > 0 {
+		return &exitError{code: 1}
+	}
+	return nil
+}
+
+// splitCommand splits exec arguments at the first "--" into flags and
+// directories before it and the command after it
+func splitCommand(args []string) (flagArgs, argv []string) {
+	for i, a := range args {
+		if a == "--" {
+			return args[:i], args[i+1:]
+		}
+	}
+	return args, nil
+}
+
+// prefixWriter writes complete lines to w, each starting with prefix, so
+// output of commands running in parallel stays readable
+type prefixWriter struct {
+	mu     *sync.Mutex
+	w      io.Writer
+	prefix string
+	buf    []byte
+}
+
+func (p *prefixWriter) Write(b []byte) (int, error) {
+	p.buf = append(p.buf, b...)
+	for {
+		i := bytes.IndexByte(p.buf, '\n')
+		if i < 0 {
+			return len(b), nil
+		}
+		p.writeLine(p.buf[:i+1])
+		p.buf = p.buf[i+1:]
+	}
+}
+
+// Close writes a final unterminated line, if any
+func (p *prefixWriter) Close() error {
+	if len(p.buf) > 0 {
+		p.writeLine(append(p.buf, '\n'))
+		p.buf = nil
+	}
+	return nil
+}
+
+func (p *prefixWriter) writeLine(line []byte) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	io.WriteString(p.w, p.prefix)
+	p.w.Write(line)
+}
+
+// groupWriter collects all output of one command and writes it to w as a
+// single block under a header when closed
+type groupWriter struct {
+	mu     *sync.Mutex
+	w      io.Writer
+	header string
+	buf    bytes.Buffer
+}
+
+func (g *groupWriter) Write(b []byte) (int, error) {
+	return g.buf.Write(b)
+}
+
+func (g *groupWriter) Close() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	io.WriteString(g.w, g.header)
+	if g.buf.Len() > 0 && !bytes.HasSuffix(g.buf.Bytes(), []byte("\n")) {
+		g.buf.WriteByte('\n')
+	}
+	_, err := g.w.Write(g.buf.Bytes())
+	return err
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/Bharath-code/git-scope/internal/gitops"
+	"github.com/Bharath-code/git-scope/internal/model"
+	"github.com/Bharath-code/git-scope/internal/scan"
+)
+
+// runFetch fetches every selected repo concurrently, printing each result
+// as it completes, and exits 1 if any fetch failed
+func runFetch(args []string, opts options) error {
+	fs := flag.NewFlagSet("fetch", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, `Usage: git-scope fetch [flags] [directories...]
+
+Fetches all remotes of every repo (or the repos selected by the filter
+flags) concurrently and prints the refreshed ahead/behind counts. git
+never prompts for credentials: repos that need them fail instead.
+
+Flags:
+`)
+		fs.PrintDefaults()
+	}
+	jobs := fs.Int("j", gitops.DefaultConcurrency, "Number of repos to fetch at once")
+	timeout := fs.Duration("timeout", gitops.DefaultTimeout, "Give up on a repo after this long")
+	var filters filterFlags
+	filters.register(fs)
+
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return nil
+		}
+		return &exitError{code: 2}
+	}
+	if err := filters.parse(); err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig(opts.ConfigPath, fs.Args())
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	repos, err := scan.ScanRootsContext(ctx, cfg.Roots, cfg.Ignore, scan.OptionsFromConfig(cfg))
+	if err != nil {
+		return fmt.Errorf("scan error: %w", err)
+	}
+	repos = filters.apply(repos)
+	if len(repos) == 0 {
+		fmt.Println("No repos to fetch")
+		return nil
+	}
+
+	start := time.Now()
+	nameWidth := longestName(repos)
+	failed, behind := 0, 0
+	op := gitops.FetchOp(scan.OptionsFromConfig(cfg))
+	for res := range gitops.Run(ctx, repos, op, gitops.Options{Concurrency: *jobs, Timeout: *timeout}) {
+		if res.Err != nil {
+			failed++
+			fmt.Printf("✗ %-*s  %v\n", nameWidth, res.Repo.Name, res.Err)
+			continue
+		}
+		if res.Repo.Status.Behind > 0 {
+			behind++
+		}
+		fmt.Printf("✓ %-*s  %-10s %.1fs\n", nameWidth, res.Repo.Name, aheadBehind(res.Repo.Status), res.Elapsed.Seconds())
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	fmt.Printf("\nFetched %d of %d repos in %.1fs", len(repos)-failed, len(repos), time.Since(start).Seconds())
+	if behind > 0 {
+		fmt.Printf(", %d behind upstream", behind)
+	}
+	fmt.Println()
+	if failed > 0 {
+		return &exitError{code: 1, err: fmt.Errorf("%d of %d fetches failed", failed, len(repos))}
+	}
+	return nil
+}
+
+// longestName returns the length of the longest repo name, for aligning
+// per-repo output
+func longestName(repos []model.Repo) int {
+	width := 0
+	for _, r := range repos {
+		if len(r.Name) > width {
+			width = len(r.Name)
+		}
+	}
+	return width
+}
+
+// aheadBehind formats the ahead/behind counts of a status, e.g. "↑2 ↓5",
+// or "up to date"
+func aheadBehind(s model.RepoStatus) string {
+	switch {
+	case s.Tracking == model.TrackingNoUpstream:
+		return "no upstream"
+	case s.Tracking == model.TrackingGone:
+		return "gone"
+	case s.Ahead > 0 && s.Behind > 0:
+		return fmt.Sprintf("↑%d ↓%d", s.Ahead, s.Behind)
+	case s.Ahead > 0:
+		return fmt.Sprintf("↑%d", s.Ahead)
+	case s.Behind > 0:
+		return fmt.Sprintf("↓%d", s.Behind)
+	}
+	return "up to date"
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+
+	"github.com/Bharath-code/git-scope/internal/filter"
+	"github.com/Bharath-code/git-scope/internal/model"
+	"github.com/Bharath-code/git-scope/internal/scan"
+)
+
+// filterFlags are the repo selection flags shared by headless commands.
+// They select the same repos as the dashboard's filter, search and sort.
+type filterFlags struct {
+	dirty  bool
+	clean  bool
+	ahead  bool
+	behind bool
+	branch string
+	name   string
+	sort   string
+	limit  int
+
+	criteria filter.Criteria
+	sortKey  filter.SortKey
+}
+
+// register adds the selection flags to fs
+func (f *filterFlags) register(fs *flag.FlagSet) {
+	fs.BoolVar(&f.dirty, "dirty", false, "Only repos with uncommitted changes")
+	fs.BoolVar(&f.clean, "clean", false, "Only repos without uncommitted changes")
+	fs.BoolVar(&f.ahead, "ahead", false, "Only repos with unpushed commits")
+	fs.BoolVar(&f.behind, "behind", false, "Only repos behind their upstream")
+	fs.StringVar(&f.branch, "branch", "", "Only repos whose branch matches this glob (e.g. 'feature/*')")
+	fs.StringVar(&f.name, "name", "", "Only repos whose name matches this regular expression")
+	fs.StringVar(&f.sort, "sort", "", "Sort by: recent, name, branch or dirty (default: path)")
+	fs.IntVar(&f.limit, "limit", 0, "Use at most this many repos (0 = all)")
+}
+
+// parse validates the flag values and builds the selection criteria.
+// Call it before scanning so bad input fails fast. Condition flags are
+// combined with OR (--dirty --behind lists repos that are either),
+// patterns with AND.
+func (f *filterFlags) parse() error {
+	f.criteria = filter.Criteria{Branch: f.branch}
+	for _, c := range []struct {
+		set  bool
+		cond filter.Condition
+	}{
+		{f.dirty, filter.Dirty},
+		{f.clean, filter.Clean},
+		{f.ahead, filter.Unpushed},
+		{f.behind, filter.Behind},
+	} {
+		if c.set {
+			f.criteria.Conditions = append(f.criteria.Conditions, c.cond)
+		}
+	}
+	if f.name != "" {
+		re, err := regexp.Compile(f.name)
+		if err != nil {
+			return fmt.Errorf("invalid -name pattern: %w", err)
+		}
+		f.criteria.Name = re
+	}
+	if _, err := path.Match(f.branch, ""); err != nil {
+		return fmt.Errorf("invalid -branch pattern: %w", err)
+	}
+	if f.sort != "" {
+		key, err := filter.ParseSortKey(f.sort)
+		if err != nil {
+			return err
+		}
+		f.sortKey = key
+	}
+	if f.limit < 0 {
+		return fmt.Errorf("invalid -limit %d", f.limit)
+	}
+	return nil
+}
+
+// apply filters, sorts and limits repos using the parsed flags
+func (f *filterFlags) apply(repos []model.Repo) []model.Repo {
+	selected := filter.Apply(repos, f.criteria)
+	// Repos arrive in the order their status was collected, which varies
+	// between runs. Sorting by path keeps the output stable and breaks
+	// ties of the other sorts the same way every time.
+	sort.SliceStable(selected, func(i, j int) bool {
+		return selected[i].Path < selected[j].Path
+	})
+	if f.sortKey != "" {
+		selected = filter.Sort(selected, f.sortKey)
+	}
+
+	// Keep linked worktrees under their parent repo, like the dashboard
+	selected = scan.GroupWorktrees(selected)
+	if f.limit > 0 && len(selected) > f.limit {
+		selected = selected[:f.limit]
+	}
+	return selected
+}
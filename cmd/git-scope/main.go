@@ -2,6 +2,7 @@ package main
 
 import (
 	"bufio"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
@@ -21,6 +22,8 @@ type options struct {
 	ConfigPath  string
 	ShowVersion bool
 	ShowHelp    bool
+	Watch       bool
+	View        string
 }
 
 func usage() {
@@ -31,7 +34,11 @@ Usage:
 
 Commands:
   (default)   Launch TUI dashboard
-  scan        Scan and print repos (JSON)
+  scan        Scan and print repos (JSON, table, CSV, NDJSON, Markdown)
+  status      Summarize repos that need attention; exit 1 if any do
+  fetch       Fetch all repos concurrently and show ahead/behind
+  pull        Fetch, then fast-forward repos that are clean and behind
+  exec        Run a command in every repo: exec [flags] -- <command>
   scan-all    Full system scan from home directory (with stats)
   init        Create config file interactively
   issue       Open git-scope GitHub issues page in browser
@@ -40,7 +47,14 @@ Commands:
 Examples:
   git-scope                    # Scan configured dirs or current dir
   git-scope ~/code ~/work      # Scan specific directories
+  git-scope -watch             # Keep the dashboard live as repos change
+  git-scope -view needs-push   # Start in a view saved in the config
   git-scope scan .             # Scan current directory (JSON)
+  git-scope scan -o table      # Aligned table (also csv, ndjson, markdown)
+  git-scope status --dirty     # Fail if any repo has uncommitted changes
+  git-scope fetch ~/code       # Fetch every repo under ~/code
+  git-scope pull --dry-run     # Show which repos would be fast-forwarded
+  git-scope exec --dirty -- git status -s   # Run a command in dirty repos
   git-scope scan-all           # Find ALL repos on your system
   git-scope init               # Setup config interactively
   git-scope issue              # Open GitHub issues page
@@ -74,11 +88,31 @@ func main() {
 		return
 	}
 
-	if err := run(cmd, dirs, opts.ConfigPath); err != nil {
+	if err := run(cmd, dirs, opts); err != nil {
+		var exitErr *exitError
+		if errors.As(err, &exitErr) {
+			if exitErr.err != nil {
+				fmt.Fprintf(os.Stderr, "git-scope: %v\n", exitErr.err)
+			}
+			os.Exit(exitErr.code)
+		}
 		log.Fatal(err)
 	}
 }
 
+// exitError makes main exit with a specific code, printing err first if set
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string {
+	if e.err != nil {
+		return e.err.Error()
+	}
+	return fmt.Sprintf("exit status %d", e.code)
+}
+
 // parseFlags defines and parses all supported CLI flags and returns
 // the resolved options. It is responsible only for flag handling and
 // does not perform any command execution. So if a user runs
@@ -95,12 +129,17 @@ func parseFlags() options {
 	flag.BoolVar(&showHelp, "h", false, "Help")
 	flag.BoolVar(&showHelp, "help", false, "Help")
 
+	watch := flag.Bool("watch", false, "Watch repos and update the dashboard live")
+	view := flag.String("view", "", "Start the dashboard in this view from the config")
+
 	flag.Parse()
 
 	return options{
 		ConfigPath:  *configPath,
 		ShowVersion: showVersion,
 		ShowHelp:    showHelp,
+		Watch:       *watch,
+		View:        *view,
 	}
 }
 
@@ -112,16 +151,16 @@ func parseCommand(args []string) (cmd string, dirs []string) {
 	}
 
 	switch args[0] {
-	case "scan", "tui", "help", "init", "scan-all", "issue":
+	case "scan", "tui", "help", "init", "scan-all", "issue", "status", "fetch", "pull", "exec":
 		return args[0], args[1:]
 	default:
 		return "tui", args // assume it's a directory
 	}
 }
 
-// run executes the requested command using the provided configuration path
-// and directories.
-func run(cmd string, dirs []string, configPath string) error {
+// run executes the requested command using the provided options and
+// directories.
+func run(cmd string, dirs []string, opts options) error {
 	switch cmd {
 	case "init":
 		runInit()
@@ -132,32 +171,32 @@ func run(cmd string, dirs []string, configPath string) error {
 	case "scan-all":
 		runScanAll()
 		return nil
+	case "scan":
+		return runScan(dirs, opts)
+	case "fetch":
+		return runFetch(dirs, opts)
+	case "pull":
+		return runPull(dirs, opts)
+	case "exec":
+		return runExec(dirs, opts)
+	case "status":
+		return runStatus(dirs, opts)
 	}
 
 	// Only commands below need config
-	cfg, err := config.Load(configPath)
+	cfg, err := loadConfig(opts.ConfigPath, dirs)
 	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
-	}
-
-	if len(dirs) > 0 {
-		cfg.Roots = expandDirs(dirs)
-	} else if !config.ConfigExists(configPath) {
-		cfg.Roots = getSmartDefaults()
+		return err
 	}
 
 	switch cmd {
-	case "scan":
-		repos, err := scan.ScanRoots(cfg.Roots, cfg.Ignore)
-		if err != nil {
-			return fmt.Errorf("scan error: %w", err)
+	case "tui", "":
+		if opts.Watch {
+			cfg.Watch = true
 		}
-		if err := scan.PrintJSON(os.Stdout, repos); err != nil {
-			return fmt.Errorf("print error: %w", err)
+		if opts.View != "" {
+			cfg.View = opts.View
 		}
-		return nil
-
-	case "tui", "":
 		if err := tui.Run(cfg); err != nil {
 			return fmt.Errorf("tui error: %w", err)
 		}
@@ -169,6 +208,23 @@ func run(cmd string, dirs []string, configPath string) error {
 	}
 }
 
+// loadConfig loads the config file and resolves the roots to scan: the
+// given directories, the configured roots, or smart defaults when there
+// is no config file
+func loadConfig(configPath string, dirs []string) (*config.Config, error) {
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if len(dirs) > 0 {
+		cfg.Roots = expandDirs(dirs)
+	} else if !config.ConfigExists(configPath) {
+		cfg.Roots = getSmartDefaults()
+	}
+	return cfg, nil
+}
+
 // expandDirs converts relative paths and ~ to absolute paths
 func expandDirs(dirs []string) []string {
 	result := make([]string, 0, len(dirs))
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+
+	"github.com/Bharath-code/git-scope/internal/gitops"
+	"github.com/Bharath-code/git-scope/internal/model"
+	"github.com/Bharath-code/git-scope/internal/scan"
+)
+
+// runPull fetches the selected repos and fast-forwards the ones that are
+// clean and strictly behind their upstream, reporting every repo it skips
+func runPull(args []string, opts options) error {
+	fs := flag.NewFlagSet("pull", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, `Usage: git-scope pull [flags] [directories...]
+
+Fetches every repo (or the repos selected by the filter flags), then
+fast-forwards the ones that are clean, on a branch with an upstream,
+with nothing in progress and strictly behind. Everything else is
+skipped with the reason. Nothing is ever merged or rebased.
+
+--dry-run still fetches, since fetching only updates remote-tracking
+branches, so the plan matches what a real pull would do. Add --no-fetch
+for an offline plan from the last fetch.
+
+Flags:
+`)
+		fs.PrintDefaults()
+	}
+	dryRun := fs.Bool("dry-run", false, "Fetch, then only list what would be pulled and skipped")
+	noFetch := fs.Bool("no-fetch", false, "Use the remote-tracking branches as they are")
+	jobs := fs.Int("j", gitops.DefaultConcurrency, "Number of repos to fetch or pull at once")
+	timeout := fs.Duration("timeout", gitops.DefaultTimeout, "Give up on a repo after this long")
+	var filters filterFlags
+	filters.register(fs)
+
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return nil
+		}
+		return &exitError{code: 2}
+	}
+	if err := filters.parse(); err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig(opts.ConfigPath, fs.Args())
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	repos, err := scan.ScanRootsContext(ctx, cfg.Roots, cfg.Ignore, scan.OptionsFromConfig(cfg))
+	if err != nil {
+		return fmt.Errorf("scan error: %w", err)
+	}
+	repos = filters.apply(repos)
+	if len(repos) == 0 {
+		fmt.Println("No repos to pull")
+		return nil
+	}
+
+	runOpts := gitops.Options{Concurrency: *jobs, Timeout: *timeout}
+	nameWidth := longestName(repos)
+	failed := 0
+
+	if !*noFetch {
+		fmt.Fprintf(os.Stderr, "Fetching %d repos...\n", len(repos))
+		fetched := make(map[string]model.Repo, len(repos))
+		for res := range gitops.Run(ctx, repos, gitops.FetchOp(scan.OptionsFromConfig(cfg)), runOpts) {
+			if res.Err != nil {
+				failed++
+				fmt.Printf("✗ %-*s  fetch: %v\n", nameWidth, res.Repo.Name, res.Err)
+				continue
+			}
+			fetched[res.Repo.Path] = res.Repo
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		// Only repos whose fetch worked have trustworthy behind counts
+		kept := repos[:0]
+		for _, r := range repos {
+			if f, ok := fetched[r.Path]; ok {
+				kept = append(kept, f)
+			}
+		}
+		repos = kept
+	}
+
+	var eligible []model.Repo
+	skipped := make(map[string][]string)
+	for _, r := range repos {
+		if reason := gitops.PullBlocker(r.Status); reason != "" {
+			skipped[reason] = append(skipped[reason], r.Name)
+			continue
+		}
+		eligible = append(eligible, r)
+	}
+
+	pulled := 0
+	if *dryRun {
+		for _, r := range eligible {
+			fmt.Printf("→ %-*s  would fast-forward %s ↓%d\n", nameWidth, r.Name, r.Status.Branch, r.Status.Behind)
+		}
+		pulled = len(eligible)
+	} else {
+		for res := range gitops.Run(ctx, eligible, gitops.PullOp(scan.OptionsFromConfig(cfg)), runOpts) {
+			var skip *gitops.SkipError
+			switch {
+			case errors.As(res.Err, &skip):
+				skipped[skip.Reason] = append(skipped[skip.Reason], res.Repo.Name)
+			case res.Err != nil:
+				failed++
+				fmt.Printf("✗ %-*s  %v\n", nameWidth, res.Repo.Name, res.Err)
+			default:
+				pulled++
+				fmt.Printf("✓ %-*s  fast-forwarded %s\n", nameWidth, res.Repo.Name, res.Repo.Status.Branch)
+			}
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+
+	printSkipped(skipped)
+
+	verb := "Pulled"
+	if *dryRun {
+		verb = "Would pull"
+	}
+	fmt.Printf("\n%s %d, skipped %d, failed %d\n", verb, pulled, countSkipped(skipped), failed)
+	if failed > 0 {
+		return &exitError{code: 1}
+	}
+	return nil
+}
+
+// printSkipped lists skipped repos grouped by reason
+func printSkipped(skipped map[string][]string) {
+	reasons := make([]string, 0, len(skipped))
+	for reason := range skipped {
+		reasons = append(reasons, reason)
+	}
+	sort.Strings(reasons)
+
+	for _, reason := range reasons {
+		names := skipped[reason]
+		sort.Strings(names)
+		fmt.Printf("– skipped (%s): %s\n", reason, strings.Join(names, ", "))
+	}
+}
+
+// countSkipped returns the number of skipped repos
+func countSkipped(skipped map[string][]string) int {
+	n := 0
+	for _, names := range skipped {
+		n += len(names)
+	}
+	return n
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/Bharath-code/git-scope/internal/scan"
+)
+
+// runScan scans the roots and prints every repo in the requested format
+func runScan(args []string, opts options) error {
+	fs := flag.NewFlagSet("scan", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, `Usage: git-scope scan [flags] [directories...]
+
+Scans the directories (or the configured roots) and prints the repos,
+optionally filtered and sorted the same way as the dashboard.
+
+Formats: %s, or a Go template executed per repo, e.g.
+  git-scope scan --format '{{.Name}} {{.Status.Branch}} {{.Status.Ahead}}'
+  git-scope scan -o table --dirty --ahead --sort recent --limit 10
+
+Flags:
+`, strings.Join(scan.Formats, ", "))
+		fs.PrintDefaults()
+	}
+	format := fs.String("format", scan.FormatJSON, "Output format")
+	fs.StringVar(format, "o", scan.FormatJSON, "Shorthand for -format")
+	var filters filterFlags
+	filters.register(fs)
+
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return nil
+		}
+		return &exitError{code: 2}
+	}
+
+	// Reject a bad format before spending time on the scan
+	printRepos, err := scan.NewPrinter(*format)
+	if err != nil {
+		return err
+	}
+	if err := filters.parse(); err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig(opts.ConfigPath, fs.Args())
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	repos, err := scan.ScanRootsContext(ctx, cfg.Roots, cfg.Ignore, scan.OptionsFromConfig(cfg))
+	if err != nil {
+		return fmt.Errorf("scan error: %w", err)
+	}
+	if err := printRepos(os.Stdout, filters.apply(repos)); err != nil {
+		return fmt.Errorf("print error: %w", err)
+	}
+	return nil
+}
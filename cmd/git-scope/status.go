@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+
+	"github.com/Bharath-code/git-scope/internal/filter"
+	"github.com/Bharath-code/git-scope/internal/model"
+	"github.com/Bharath-code/git-scope/internal/scan"
+)
+
+// Exit codes of the status command
+const (
+	statusExitClean     = 0
+	statusExitAttention = 1
+	statusExitError     = 2
+)
+
+// runStatus scans the roots, prints the repos in any of the requested
+// conditions and exits with statusExitAttention if there are any. With no
+// condition flags it checks every condition in filter.Attention.
+func runStatus(args []string, opts options) error {
+	fs := flag.NewFlagSet("status", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, `Usage: git-scope status [flags] [directories...]
+
+Lists repos that are in any of the selected conditions and exits 1 if
+there are any, 0 if there are none and 2 on errors. Without condition
+flags, checks: dirty, unpushed, stashed, in-progress, errored.
+
+Flags:
+`)
+		fs.PrintDefaults()
+	}
+
+	conds := map[filter.Condition]*bool{
+		filter.Dirty:      fs.Bool("dirty", false, "Uncommitted changes (staged, modified, untracked or conflicted)"),
+		filter.Unpushed:   fs.Bool("unpushed", false, "Commits not pushed to the upstream or to any remote"),
+		filter.Behind:     fs.Bool("behind", false, "Commits on the upstream not pulled yet"),
+		filter.Stashed:    fs.Bool("stashed", false, "One or more stashes"),
+		filter.InProgress: fs.Bool("in-progress", false, "Unfinished rebase, merge, cherry-pick, revert or bisect"),
+		filter.NoUpstream: fs.Bool("no-upstream", false, "Unpushed commits on a branch with no (or a deleted) upstream"),
+		filter.Errored:    fs.Bool("errored", false, "git status failed or timed out"),
+	}
+	quiet := fs.Bool("q", false, "Print nothing, only set the exit code")
+
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return nil
+		}
+		return &exitError{code: statusExitError}
+	}
+
+	var selected []filter.Condition
+	for _, c := range []filter.Condition{
+		filter.Dirty, filter.Unpushed, filter.Behind, filter.Stashed,
+		filter.InProgress, filter.NoUpstream, filter.Errored,
+	} {
+		if *conds[c] {
+			selected = append(selected, c)
+		}
+	}
+	if len(selected) == 0 {
+		selected = filter.Attention
+	}
+
+	cfg, err := loadConfig(opts.ConfigPath, fs.Args())
+	if err != nil {
+		return &exitError{code: statusExitError, err: err}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	repos, err := scan.ScanRootsContext(ctx, cfg.Roots, cfg.Ignore, scan.OptionsFromConfig(cfg))
+	if err != nil {
+		return &exitError{code: statusExitError, err: fmt.Errorf("scan error: %w", err)}
+	}
+
+	var matched []model.Repo
+	for _, r := range repos {
+		if filter.MatchAny(r, selected) {
+			matched = append(matched, r)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		return matched[i].Path < matched[j].Path
+	})
+
+	if !*quiet {
+		printStatusSummary(repos, matched, selected)
+	}
+
+	if len(matched) > 0 {
+		return &exitError{code: statusExitAttention}
+	}
+	return nil
+}
+
+// printStatusSummary prints one line per matched repo followed by a total
+func printStatusSummary(repos, matched []model.Repo, selected []filter.Condition) {
+	names := make([]string, len(selected))
+	for i, c := range selected {
+		names[i] = string(c)
+	}
+	checked := strings.Join(names, ", ")
+
+	if len(matched) == 0 {
+		fmt.Printf("✓ %d repos, none %s\n", len(repos), checked)
+		return
+	}
+
+	nameWidth, branchWidth := 0, 0
+	for _, r := range matched {
+		if len(r.Name) > nameWidth {
+			nameWidth = len(r.Name)
+		}
+		if len(r.Status.Branch) > branchWidth {
+			branchWidth = len(r.Status.Branch)
+		}
+	}
+
+	for _, r := range matched {
+		reasons := []string{}
+		for _, c := range filter.Matching(r, selected) {
+			reasons = append(reasons, c.Describe(r))
+		}
+		fmt.Printf("%-*s  %-*s  %s  %s\n", nameWidth, r.Name, branchWidth, r.Status.Branch,
+			strings.Join(reasons, ", "), displayPath(r.Path))
+	}
+
+	fmt.Printf("\n✗ %d of %d repos: %s\n", len(matched), len(repos), checked)
+}
+
+// displayPath shortens a path inside the home directory to ~/...
+func displayPath(path string) string {
+	home, err := os.UserHomeDir()
+	if err != nil || !strings.HasPrefix(path, home+string(os.PathSeparator)) {
+		return path
+	}
+	return "~" + strings.TrimPrefix(path, home)
+}
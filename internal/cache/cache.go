@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/Bharath-code/git-scope/internal/model"
@@ -14,6 +15,9 @@ type CacheData struct {
 	Repos     []model.Repo `json:"repos"`
 	Timestamp time.Time    `json:"timestamp"`
 	Roots     []string     `json:"roots"`
+	// Fingerprints maps repo paths to the fingerprint taken when their
+	// status was collected
+	Fingerprints map[string]string `json:"fingerprints,omitempty"`
 }
 
 // Store interface for caching repo data
@@ -57,16 +61,45 @@ func (s *FileStore) Load() (*CacheData, error) {
 		return nil, err
 	}
 
+	for i, r := range cache.Repos {
+		cache.Repos[i].Fingerprint = cache.Fingerprints[r.Path]
+	}
+
 	s.data = &cache
 	return &cache, nil
 }
 
-// Save writes repos to cache file
+// Save writes repos to cache file. Cached repos outside of roots are kept,
+// so the cache can keep serving a superset of the roots scanned last.
 func (s *FileStore) Save(repos []model.Repo, roots []string) error {
+	if s.data == nil {
+		_, _ = s.Load()
+	}
+
 	cache := CacheData{
-		Repos:     repos,
-		Timestamp: time.Now(),
-		Roots:     roots,
+		Timestamp:    time.Now(),
+		Fingerprints: make(map[string]string, len(repos)),
+	}
+
+	if s.data != nil {
+		for _, r := range s.data.Repos {
+			if !isUnder(r.Path, roots) {
+				cache.Repos = append(cache.Repos, r)
+			}
+		}
+		for _, root := range s.data.Roots {
+			if !isUnder(root, roots) {
+				cache.Roots = append(cache.Roots, root)
+			}
+		}
+	}
+	cache.Repos = append(cache.Repos, repos...)
+	cache.Roots = append(cache.Roots, roots...)
+
+	for _, r := range cache.Repos {
+		if r.Fingerprint != "" {
+			cache.Fingerprints[r.Path] = r.Fingerprint
+		}
 	}
 
 	// Ensure cache directory exists
@@ -80,7 +113,12 @@ func (s *FileStore) Save(repos []model.Repo, roots []string) error {
 		return err
 	}
 
-	return os.WriteFile(s.path, data, 0644)
+	if err := os.WriteFile(s.path, data, 0644); err != nil {
+		return err
+	}
+
+	s.data = &cache
+	return nil
 }
 
 // IsValid checks if cache is still valid based on max age
@@ -104,6 +142,66 @@ func (s *FileStore) IsSameRoots(roots []string) bool {
 	return true
 }
 
+// CoversRoots checks if every root is one of the cached roots or lies
+// inside one of them
+func (s *FileStore) CoversRoots(roots []string) bool {
+	if s.data == nil || len(roots) == 0 {
+		return false
+	}
+	for _, r := range roots {
+		if !isUnder(r, s.data.Roots) {
+			return false
+		}
+	}
+	return true
+}
+
+// ReposUnder returns the cached repos that live inside any of roots
+func (s *FileStore) ReposUnder(roots []string) []model.Repo {
+	if s.data == nil {
+		return nil
+	}
+	repos := make([]model.Repo, 0, len(s.data.Repos))
+	for _, r := range s.data.Repos {
+		if isUnder(r.Path, roots) {
+			repos = append(repos, r)
+		}
+	}
+	return repos
+}
+
+// Lookup returns the cached status of repo if its fingerprint still
+// matches the one recorded when that status was collected. repo must
+// carry a freshly computed Fingerprint.
+func (s *FileStore) Lookup(repo model.Repo) (model.RepoStatus, bool) {
+	if s.data == nil || repo.Fingerprint == "" {
+		return model.RepoStatus{}, false
+	}
+	if s.data.Fingerprints[repo.Path] != repo.Fingerprint {
+		return model.RepoStatus{}, false
+	}
+	for _, r := range s.data.Repos {
+		if r.Path == repo.Path {
+			return r.Status, r.Status.ScanError == ""
+		}
+	}
+	return model.RepoStatus{}, false
+}
+
+// isUnder reports whether path is one of roots or nested inside one
+func isUnder(path string, roots []string) bool {
+	for _, root := range roots {
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			continue
+		}
+		if rel == "." || (rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))) {
+			return true
+		}
+	}
+	return false
+}
+
 // GetTimestamp returns the cache timestamp
 func (s *FileStore) GetTimestamp() time.Time {
 	if s.data == nil {
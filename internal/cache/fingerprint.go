@@ -0,0 +1,66 @@
+package cache
+
+import (
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/Bharath-code/git-scope/internal/model"
+)
+
+// statusVersion changes whenever RepoStatus gains fields or their values
+// change meaning, so statuses cached before are collected again
+const statusVersion = 3
+
+// Fingerprint summarizes the state of a repo's git dir: the git dir itself
+// (creating or removing HEAD, index, MERGE_HEAD and friends renames
+// entries in it), the index, HEAD, FETCH_HEAD, the config holding the
+// remotes, packed and loose refs and the stash. It changes whenever git
+// writes to the repo, so an unchanged fingerprint means the parts of a
+// cached status that only depend on commits and refs can be reused. It
+// does not cover the working tree: editing a tracked file in place
+// changes nothing git knows about until the file is staged.
+func Fingerprint(repo model.Repo) string {
+	if repo.GitDir == "" {
+		return ""
+	}
+	commonDir := repo.CommonDir
+	if commonDir == "" {
+		commonDir = repo.GitDir
+	}
+
+	h := fnv.New64a()
+	fmt.Fprintf(h, "v%d;", statusVersion)
+	stamp := func(path string) {
+		info, err := os.Stat(path)
+		if err != nil {
+			fmt.Fprintf(h, "%s:-;", path)
+			return
+		}
+		fmt.Fprintf(h, "%s:%d:%d;", path, info.ModTime().UnixNano(), info.Size())
+	}
+
+	stamp(repo.GitDir)
+	stamp(filepath.Join(repo.GitDir, "index"))
+	stamp(filepath.Join(repo.GitDir, "HEAD"))
+	stamp(filepath.Join(repo.GitDir, "FETCH_HEAD"))
+	stamp(filepath.Join(commonDir, "config"))
+	stamp(filepath.Join(commonDir, "packed-refs"))
+	stamp(filepath.Join(commonDir, "refs", "stash"))
+
+	// Loose refs are replaced by renaming a lock file, which touches the
+	// directory holding them
+	_ = filepath.WalkDir(filepath.Join(commonDir, "refs"), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			stamp(path)
+		}
+		return nil
+	})
+
+	return fmt.Sprintf("%016x", h.Sum64())
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -14,6 +15,54 @@ type Config struct {
 	Roots  []string `yaml:"roots"`
 	Ignore []string `yaml:"ignore"`
 	Editor string   `yaml:"editor"`
+	// Terminal opens a new terminal window in a repo, e.g.
+	// "tmux new-window -c {path}". {path} is replaced by the repo path,
+	// which is appended if it does not appear.
+	Terminal string `yaml:"terminal,omitempty"`
+	// ScanConcurrency limits how many repos are queried at once (0 = CPU count)
+	ScanConcurrency int `yaml:"scan_concurrency,omitempty"`
+	// ScanTimeout bounds the status query of a single repo, e.g. "10s"
+	ScanTimeout time.Duration `yaml:"scan_timeout,omitempty"`
+	// Watch keeps the dashboard live by watching repos for changes
+	Watch bool `yaml:"watch,omitempty"`
+	// Columns lists the table columns to show, in order; empty shows the
+	// default columns
+	Columns []string `yaml:"columns,omitempty"`
+	// Views are named presets of the dashboard
+	Views []View `yaml:"views,omitempty"`
+	// View is the name of the view the dashboard starts in
+	View string `yaml:"view,omitempty"`
+	// Group is how the dashboard groups repos: root, parent, remote or
+	// tag; empty lists them flat
+	Group string `yaml:"group,omitempty"`
+	// Tags label repos by path for grouping by tag
+	Tags []Tag `yaml:"tags,omitempty"`
+}
+
+// Tag labels the repos at or below any of its paths, e.g. "client-a"
+// for ~/work/clientA. Paths may be globs such as ~/work/*-api.
+type Tag struct {
+	Name  string   `yaml:"name"`
+	Paths []string `yaml:"paths"`
+}
+
+// View is a named combination of search query, filter, sort, columns and
+// grouping of the dashboard, e.g. "needs-push" for `query: ahead>0`
+type View struct {
+	Name string `yaml:"name"`
+	// Query is a search query, as typed after /
+	Query string `yaml:"query,omitempty"`
+	// Filter is one of dirty, clean, stashed, in-progress, no-upstream
+	// or unpushed; empty shows all repos
+	Filter string `yaml:"filter,omitempty"`
+	// Sort is one of dirty, name, branch or recent
+	Sort string `yaml:"sort,omitempty"`
+	// Columns lists the table columns to show, in order; empty shows the
+	// columns of the config
+	Columns []string `yaml:"columns,omitempty"`
+	// Group is one of root, parent, remote, tag or none; empty groups
+	// like the config
+	Group string `yaml:"group,omitempty"`
 }
 
 // defaultConfig returns sensible defaults
@@ -0,0 +1,205 @@
+package filter
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Bharath-code/git-scope/internal/model"
+)
+
+// Condition is a repository state that repos can be filtered on
+type Condition string
+
+const (
+	Dirty      Condition = "dirty"
+	Clean      Condition = "clean"
+	Unpushed   Condition = "unpushed"
+	Behind     Condition = "behind"
+	Stashed    Condition = "stashed"
+	InProgress Condition = "in-progress"
+	NoUpstream Condition = "no-upstream"
+	Errored    Condition = "errored"
+)
+
+// Attention lists the conditions that mean a repo holds work that could
+// be lost or forgotten
+var Attention = []Condition{Dirty, Unpushed, Stashed, InProgress, Errored}
+
+// Match reports whether the repo is in this condition
+func (c Condition) Match(r model.Repo) bool {
+	s := r.Status
+	switch c {
+	case Dirty:
+		return s.IsDirty
+	case Clean:
+		return !s.IsDirty && s.ScanError == ""
+	case Unpushed:
+		return s.Ahead > 0
+	case Behind:
+		return s.Behind > 0
+	case Stashed:
+		return s.Stashes > 0
+	case InProgress:
+		return s.IsInProgress()
+	case NoUpstream:
+		return s.HasUnpushedWork()
+	case Errored:
+		return s.ScanError != ""
+	}
+	return false
+}
+
+// Describe returns a short explanation of why the repo is in this
+// condition, e.g. "2 unpushed" or "rebase"
+func (c Condition) Describe(r model.Repo) string {
+	s := r.Status
+	switch c {
+	case Unpushed:
+		return fmt.Sprintf("%d unpushed", s.Ahead)
+	case Behind:
+		return fmt.Sprintf("%d behind", s.Behind)
+	case Stashed:
+		return plural(s.Stashes, "stash", "stashes")
+	case InProgress:
+		parts := []string{}
+		if s.Operation != "" {
+			parts = append(parts, string(s.Operation))
+		}
+		if s.Conflicts > 0 {
+			parts = append(parts, plural(s.Conflicts, "conflict", "conflicts"))
+		}
+		return strings.Join(parts, ", ")
+	case NoUpstream:
+		return "no upstream"
+	case Errored:
+		return "error: " + s.ScanError
+	}
+	return string(c)
+}
+
+// MatchAny reports whether the repo is in at least one of conds
+func MatchAny(r model.Repo, conds []Condition) bool {
+	for _, c := range conds {
+		if c.Match(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// Matching returns the conditions of conds the repo is in
+func Matching(r model.Repo, conds []Condition) []Condition {
+	var matched []Condition
+	for _, c := range conds {
+		if c.Match(r) {
+			matched = append(matched, c)
+		}
+	}
+	return matched
+}
+
+// plural formats a count with the singular or plural noun
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, one)
+	}
+	return fmt.Sprintf("%d %s", n, many)
+}
+
+// Criteria selects repos. A repo matches when it is in at least one of
+// Conditions (or Conditions is empty) and every set pattern matches.
+type Criteria struct {
+	Conditions []Condition
+	// Query is a parsed search query, see ParseQuery
+	Query *Query
+	// Name is matched against the repo name
+	Name *regexp.Regexp
+	// Branch is a glob (path.Match syntax) matched against the branch
+	Branch string
+}
+
+// Match reports whether the repo satisfies the criteria
+func (c Criteria) Match(r model.Repo) bool {
+	if len(c.Conditions) > 0 && !MatchAny(r, c.Conditions) {
+		return false
+	}
+	if c.Query != nil && !c.Query.Match(r) {
+		return false
+	}
+	if c.Name != nil && !c.Name.MatchString(r.Name) {
+		return false
+	}
+	if c.Branch != "" {
+		if ok, _ := path.Match(c.Branch, r.Status.Branch); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Apply returns the repos that match the criteria, in their original order
+func Apply(repos []model.Repo, c Criteria) []model.Repo {
+	matched := make([]model.Repo, 0, len(repos))
+	for _, r := range repos {
+		if c.Match(r) {
+			matched = append(matched, r)
+		}
+	}
+	return matched
+}
+
+// SortKey is an order repos can be listed in
+type SortKey string
+
+const (
+	SortDirty  SortKey = "dirty"
+	SortName   SortKey = "name"
+	SortBranch SortKey = "branch"
+	SortRecent SortKey = "recent"
+)
+
+// SortKeys lists the valid sort keys, for help texts and validation
+var SortKeys = []SortKey{SortRecent, SortName, SortBranch, SortDirty}
+
+// ParseSortKey validates a sort key given by the user
+func ParseSortKey(s string) (SortKey, error) {
+	for _, k := range SortKeys {
+		if string(k) == s {
+			return k, nil
+		}
+	}
+	return "", fmt.Errorf("unknown sort %q (want recent, name, branch or dirty)", s)
+}
+
+// Sort returns a sorted copy of repos. Dirty repos sort first by name,
+// and recent puts the latest commit first.
+func Sort(repos []model.Repo, key SortKey) []model.Repo {
+	sorted := make([]model.Repo, len(repos))
+	copy(sorted, repos)
+
+	switch key {
+	case SortDirty:
+		sort.SliceStable(sorted, func(i, j int) bool {
+			if sorted[i].Status.IsDirty != sorted[j].Status.IsDirty {
+				return sorted[i].Status.IsDirty
+			}
+			return sorted[i].Name < sorted[j].Name
+		})
+	case SortName:
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return sorted[i].Name < sorted[j].Name
+		})
+	case SortBranch:
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return sorted[i].Status.Branch < sorted[j].Status.Branch
+		})
+	case SortRecent:
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return sorted[i].Status.LastCommit.After(sorted[j].Status.LastCommit)
+		})
+	}
+	return sorted
+}
@@ -0,0 +1,170 @@
+package filter
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Fuzzy match scores
+const (
+	fuzzyMatch       = 16 // every matched character
+	fuzzyStart       = 10 // match at the start of the text
+	fuzzyBoundary    = 8  // match after a separator such as - / _ . or space
+	fuzzyCamel       = 6  // match at a lower-to-upper case change
+	fuzzyConsecutive = 8  // match right after the previous match
+	fuzzyGapStart    = 3  // penalty for starting a gap between matches
+	fuzzyGapExtend   = 1  // penalty for each further skipped character
+)
+
+// FuzzyMatch reports whether the characters of pattern appear in s in
+// order, ignoring case, e.g. "gsc" in "git-scope". It returns a score
+// that rewards matches at word starts and runs of consecutive matches,
+// and the rune indexes of s that matched.
+func FuzzyMatch(pattern, s string) (score int, positions []int, ok bool) {
+	p := []rune(pattern)
+	if len(p) == 0 {
+		return 0, nil, true
+	}
+	text := []rune(s)
+	lower := make([]rune, len(text))
+	for i, c := range text {
+		lower[i] = unicode.ToLower(c)
+	}
+	for i, c := range p {
+		p[i] = unicode.ToLower(c)
+	}
+
+	// Match greedily forward from every place the first character occurs,
+	// then backward from the last match to tighten the span, and keep the
+	// best scoring alignment
+	best := -1
+	for start := range lower {
+		if lower[start] != p[0] {
+			continue
+		}
+		end, j := start, 0
+		for ; end < len(lower) && j < len(p); end++ {
+			if lower[end] == p[j] {
+				j++
+			}
+		}
+		if j < len(p) {
+			// Later starts cannot match either
+			break
+		}
+		pos := make([]int, len(p))
+		j = len(p) - 1
+		for i := end - 1; j >= 0; i-- {
+			if lower[i] == p[j] {
+				pos[j] = i
+				j--
+			}
+		}
+		if sc := fuzzyScore(text, pos); best < 0 || sc > score {
+			best, score, positions = start, sc, pos
+		}
+	}
+	return score, positions, best >= 0
+}
+
+// fuzzyScore scores the matched positions of text
+func fuzzyScore(text []rune, pos []int) int {
+	score := 0
+	for k, i := range pos {
+		prev := -1
+		if k > 0 {
+			prev = pos[k-1]
+		}
+		score += fuzzyCharScore(text, i, prev)
+	}
+	return score
+}
+
+// fuzzyCharScore scores a match at text[i] following a match at prev, or
+// -1 for the first match
+func fuzzyCharScore(text []rune, i, prev int) int {
+	score := fuzzyMatch
+	switch {
+	case i == 0:
+		score += fuzzyStart
+	case isSeparator(text[i-1]):
+		score += fuzzyBoundary
+	case unicode.IsLower(text[i-1]) && unicode.IsUpper(text[i]):
+		score += fuzzyCamel
+	}
+	if prev >= 0 {
+		if gap := i - prev - 1; gap == 0 {
+			score += fuzzyConsecutive
+		} else {
+			score -= fuzzyGapStart + (gap-1)*fuzzyGapExtend
+		}
+	}
+	return score
+}
+
+// wordMatch is a stricter FuzzyMatch: every matched character must start
+// a word or follow the previous match, so "gsc" matches "work/git-scope"
+// but not "clients/gasoline". It returns the best scoring alignment.
+func wordMatch(pattern, s string) (score int, positions []int, ok bool) {
+	p := []rune(strings.ToLower(pattern))
+	if len(p) == 0 {
+		return 0, nil, true
+	}
+	text := []rune(s)
+	lower := make([]rune, len(text))
+	for i, c := range text {
+		lower[i] = unicode.ToLower(c)
+	}
+
+	type state struct{ k, prev int }
+	type result struct {
+		score int
+		pos   []int
+		ok    bool
+	}
+	memo := make(map[state]result)
+
+	// best matches p[k:] after a match at prev
+	var best func(k, prev int) result
+	best = func(k, prev int) result {
+		if k == len(p) {
+			return result{ok: true}
+		}
+		if r, seen := memo[state{k, prev}]; seen {
+			return r
+		}
+		var r result
+		for i := prev + 1; i < len(lower); i++ {
+			if lower[i] != p[k] || !(k > 0 && i == prev+1 || isWordStart(text, i)) {
+				continue
+			}
+			rest := best(k+1, i)
+			if !rest.ok {
+				continue
+			}
+			if sc := fuzzyCharScore(text, i, prev) + rest.score; !r.ok || sc > r.score {
+				r = result{score: sc, pos: append([]int{i}, rest.pos...), ok: true}
+			}
+		}
+		memo[state{k, prev}] = r
+		return r
+	}
+
+	r := best(0, -1)
+	return r.score, r.pos, r.ok
+}
+
+// isWordStart reports whether text[i] starts a word: it is the first
+// character, follows a separator or starts a camelCase hump
+func isWordStart(text []rune, i int) bool {
+	return i == 0 || isSeparator(text[i-1]) || unicode.IsLower(text[i-1]) && unicode.IsUpper(text[i])
+}
+
+// isSeparator reports whether c separates words in names and paths
+func isSeparator(c rune) bool {
+	switch c {
+	case '-', '_', '.', '/', '\\', ' ':
+		return true
+	}
+	return false
+}
@@ -0,0 +1,178 @@
+package filter
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Bharath-code/git-scope/internal/model"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		pattern, s string
+		positions  []int
+		ok         bool
+	}{
+		{"gsc", "git-scope", []int{0, 4, 5}, true},
+		{"GSC", "git-scope", []int{0, 4, 5}, true},
+		{"apigw", "api-gateway", []int{0, 1, 2, 4, 8}, true},
+		{"gsc", "gasoline-scout", []int{0, 9, 10}, true},
+		{"gsc", "gsc-tools", []int{0, 1, 2}, true},
+		{"gs", "GitScope", []int{0, 3}, true},
+		{"scope", "git-scope", []int{4, 5, 6, 7, 8}, true},
+		{"日本", "日本語", []int{0, 1}, true},
+		{"", "anything", nil, true},
+		{"xyz", "git-scope", nil, false},
+		{"scg", "git-scope", nil, false},
+		{"gitscopes", "git-scope", nil, false},
+	}
+
+	for _, tt := range tests {
+		_, pos, ok := FuzzyMatch(tt.pattern, tt.s)
+		if ok != tt.ok || !reflect.DeepEqual(pos, tt.positions) {
+			t.Errorf("FuzzyMatch(%q, %q) = %v, %v; want %v, %v", tt.pattern, tt.s, pos, ok, tt.positions, tt.ok)
+		}
+	}
+}
+
+func TestFuzzyMatchScoreOrder(t *testing.T) {
+	// Each pattern scores the candidates best first
+	tests := []struct {
+		pattern    string
+		candidates []string
+	}{
+		{"gsc", []string{"gsc-tools", "git-scope", "gasoline-scout", "bigsaucecan"}},
+		{"apigw", []string{"api-gateway", "rapid-growth"}},
+		{"scope", []string{"scope", "git-scope", "my-s-c-o-p-e"}},
+	}
+
+	for _, tt := range tests {
+		prev, prevName := 0, ""
+		for i, c := range tt.candidates {
+			score, _, ok := FuzzyMatch(tt.pattern, c)
+			if !ok {
+				t.Errorf("FuzzyMatch(%q, %q) did not match", tt.pattern, c)
+				continue
+			}
+			if i > 0 && score >= prev {
+				t.Errorf("FuzzyMatch(%q): %q scores %d, not below %q with %d", tt.pattern, c, score, prevName, prev)
+			}
+			prev, prevName = score, c
+		}
+	}
+}
+
+func TestWordMatch(t *testing.T) {
+	tests := []struct {
+		pattern, s string
+		positions  []int
+		ok         bool
+	}{
+		{"gsc", "work/git-scope", []int{5, 9, 10}, true},
+		{"wgs", "work/git-scope", []int{0, 5, 9}, true},
+		{"cw", "clients/acme/web", []int{0, 13}, true},
+		{"acweb", "clients/acme/web", []int{8, 9, 13, 14, 15}, true},
+		{"gsc", "clients/gasoline", nil, false},
+		{"ce", "clients/acme/web", nil, false},
+		{"ts", "work/GitScope", nil, false},
+		{"gs", "work/GitScope", []int{5, 8}, true},
+	}
+
+	for _, tt := range tests {
+		_, pos, ok := wordMatch(tt.pattern, tt.s)
+		if ok != tt.ok || !reflect.DeepEqual(pos, tt.positions) {
+			t.Errorf("wordMatch(%q, %q) = %v, %v; want %v, %v", tt.pattern, tt.s, pos, ok, tt.positions, tt.ok)
+		}
+	}
+}
+
+func TestQueryRank(t *testing.T) {
+	repos := []model.Repo{
+		{Name: "gasoline-scout", Path: "/code/gasoline-scout"},
+		{Name: "docs", Path: "/code/tools/gsc/docs"},
+		{Name: "git-scope", Path: "/code/git-scope"},
+		{Name: "api-gateway", Path: "/code/api-gateway"},
+		{Name: "gsc-tools", Path: "/code/gsc-tools"},
+	}
+
+	q, err := ParseQuery("gsc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	q.Roots = []string{"/code"}
+
+	var matched []model.Repo
+	for _, r := range repos {
+		if q.Match(r) {
+			matched = append(matched, r)
+		}
+	}
+	var got []string
+	for _, r := range q.Rank(matched) {
+		got = append(got, r.Name)
+	}
+	want := []string{"gsc-tools", "git-scope", "gasoline-scout", "docs"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Rank = %v, want %v", got, want)
+	}
+}
+
+func TestQueryTextFallsBackToPath(t *testing.T) {
+	tests := []struct {
+		name, path string
+		text       string
+		ok         bool
+	}{
+		// The name matches, so the path is not looked at
+		{"api", "/code/clients/acme/api", "api", true},
+		// The name does not match, but words of the path do
+		{"web", "/code/clients/acme/web", "acme", true},
+		{"web", "/code/clients/acme/web", "cw", true},
+		// Scattered letters of the path don't count
+		{"web", "/code/clients/acme/web", "ce", false},
+		{"web", "/code/clients/acme/web", "lnt", false},
+		// Directories above the roots never match
+		{"web", "/code/clients/acme/web", "code", false},
+	}
+
+	for _, tt := range tests {
+		q, err := ParseQuery(tt.text)
+		if err != nil {
+			t.Fatal(err)
+		}
+		q.Roots = []string{"/code"}
+		r := model.Repo{Name: tt.name, Path: tt.path}
+		if got := q.Match(r); got != tt.ok {
+			t.Errorf("%q matches %s = %v, want %v", tt.text, tt.path, got, tt.ok)
+		}
+	}
+}
+
+func TestQueryHighlights(t *testing.T) {
+	r := model.Repo{
+		Name:   "git-scope",
+		Path:   "/code/git-scope",
+		Status: model.RepoStatus{Branch: "feat/gsc"},
+	}
+	tests := []struct {
+		query        string
+		name, branch []int
+	}{
+		{"gsc", []int{0, 4, 5}, []int{5, 6, 7}},
+		{"git scope", []int{0, 1, 2, 4, 5, 6, 7, 8}, nil},
+		{"-gsc", nil, nil},
+		{"dirty", nil, nil},
+		{"feat", nil, []int{0, 1, 2, 3}},
+	}
+
+	for _, tt := range tests {
+		q, err := ParseQuery(tt.query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		name, branch := q.Highlights(r)
+		if !reflect.DeepEqual(name, tt.name) || !reflect.DeepEqual(branch, tt.branch) {
+			t.Errorf("Highlights(%q) = %v, %v; want %v, %v", tt.query, name, branch, tt.name, tt.branch)
+		}
+	}
+}
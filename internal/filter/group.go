@@ -0,0 +1,180 @@
+package filter
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Bharath-code/git-scope/internal/model"
+)
+
+// GroupKey is a way repos can be grouped
+type GroupKey string
+
+const (
+	GroupNone   GroupKey = ""
+	GroupRoot   GroupKey = "root"
+	GroupParent GroupKey = "parent"
+	GroupRemote GroupKey = "remote"
+	GroupTag    GroupKey = "tag"
+)
+
+// GroupKeys lists the valid group keys, for help texts and validation
+var GroupKeys = []GroupKey{GroupRoot, GroupParent, GroupRemote, GroupTag}
+
+// otherGroups names the group of repos that have no root, remote or tag
+var otherGroups = map[GroupKey]string{
+	GroupRoot:   "(outside roots)",
+	GroupRemote: "(no remote)",
+	GroupTag:    "(untagged)",
+}
+
+// ParseGroupKey validates a group key given by the user. "none" lists
+// repos without groups.
+func ParseGroupKey(s string) (GroupKey, error) {
+	if s == "none" {
+		return GroupNone, nil
+	}
+	for _, k := range GroupKeys {
+		if string(k) == s {
+			return k, nil
+		}
+	}
+	return "", fmt.Errorf("unknown group %q (want root, parent, remote, tag or none)", s)
+}
+
+// Tag labels the repos at or below any of Paths, which may be globs,
+// e.g. "client-a" for ~/work/clientA
+type Tag struct {
+	Name  string
+	Paths []string
+}
+
+// Validate reports a tag without a name or paths, or with a bad glob
+func (t Tag) Validate() error {
+	if t.Name == "" {
+		return fmt.Errorf("tag has no name")
+	}
+	if len(t.Paths) == 0 {
+		return fmt.Errorf("tag %q has no paths", t.Name)
+	}
+	for _, p := range t.Paths {
+		if _, err := filepath.Match(p, ""); err != nil {
+			return fmt.Errorf("tag %q: bad pattern %q", t.Name, p)
+		}
+	}
+	return nil
+}
+
+// Match reports whether the repo is at or below one of the tag's paths
+func (t Tag) Match(r model.Repo) bool {
+	for _, p := range t.Paths {
+		pattern, err := filepath.Abs(expandHome(p))
+		if err != nil {
+			continue
+		}
+		for dir := r.Path; ; {
+			if ok, _ := filepath.Match(pattern, dir); ok {
+				return true
+			}
+			parent := filepath.Dir(dir)
+			if parent == dir {
+				break
+			}
+			dir = parent
+		}
+	}
+	return false
+}
+
+// Group is a named group of repos
+type Group struct {
+	Name  string
+	Repos []model.Repo
+}
+
+// Grouping splits repos into groups by Key. Roots are the roots repos
+// were found under and Tags the tags to group by.
+type Grouping struct {
+	Key   GroupKey
+	Roots []string
+	Tags  []Tag
+}
+
+// Names returns the names of the groups a repo belongs to, or none when
+// it has no root, remote or tag. Only tags can put a repo in several
+// groups.
+func (g Grouping) Names(r model.Repo) []string {
+	switch g.Key {
+	case GroupRoot:
+		if root, _, ok := containingRoot(g.Roots, r.Path); ok {
+			return []string{root}
+		}
+	case GroupParent:
+		return []string{filepath.Dir(r.Path)}
+	case GroupRemote:
+		if owner := remoteOwner(r.Status.Remote); owner != "" {
+			return []string{owner}
+		}
+	case GroupTag:
+		var names []string
+		for _, t := range g.Tags {
+			if t.Match(r) {
+				names = append(names, t.Name)
+			}
+		}
+		return names
+	}
+	return nil
+}
+
+// Apply splits repos into groups, keeping their order within each group.
+// Groups are sorted by name, followed by the repos in no group.
+func (g Grouping) Apply(repos []model.Repo) []Group {
+	var groups []Group
+	index := make(map[string]int)
+	var other []model.Repo
+	for _, r := range repos {
+		names := g.Names(r)
+		if len(names) == 0 {
+			other = append(other, r)
+			continue
+		}
+		for _, name := range names {
+			i, ok := index[name]
+			if !ok {
+				i = len(groups)
+				index[name] = i
+				groups = append(groups, Group{Name: name})
+			}
+			groups[i].Repos = append(groups[i].Repos, r)
+		}
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		return strings.ToLower(groups[i].Name) < strings.ToLower(groups[j].Name)
+	})
+	if len(other) > 0 {
+		groups = append(groups, Group{Name: otherGroups[g.Key], Repos: other})
+	}
+	return groups
+}
+
+// remoteOwner returns the host and owner of a remote URL, e.g.
+// "github.com/owner" for git@github.com:owner/repo.git
+func remoteOwner(remote string) string {
+	host, p := model.RemoteLocation(remote)
+	owner := path.Dir(p)
+	if owner == "." {
+		owner = ""
+	}
+	switch {
+	case host == "":
+		return owner
+	case owner == "":
+		return host
+	}
+	return host + "/" + owner
+}
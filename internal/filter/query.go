@@ -0,0 +1,529 @@
+package filter
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/Bharath-code/git-scope/internal/model"
+)
+
+// StaleAge is how long ago the last commit of a stale repo was made
+const StaleAge = 30 * 24 * time.Hour
+
+// Query is a parsed search query. It is a list of terms separated by
+// spaces that must all match:
+//
+//	dirty ahead>0 branch:feat/* path:~/work -stale age<7d
+//
+// A term is a keyword such as dirty or stashed, a field predicate such as
+// ahead>0 or name:api, or plain text fuzzy matched against the name and
+// branch, or else the words of the path below Roots, so "gsc" finds
+// git-scope. A leading - negates a term. String fields match a
+// substring, a glob when the value contains * ? or [, or a regexp written
+// as /re/; all are case-insensitive. Quoted text is always plain text.
+type Query struct {
+	// Roots are the scanned directories. Text is matched against repo
+	// paths relative to them, so that parent directories don't match.
+	Roots []string
+
+	source string
+	terms  []queryTerm
+}
+
+// QueryError reports why a query does not parse, with the byte range of
+// the offending term
+type QueryError struct {
+	Start, End int
+	Msg        string
+}
+
+func (e *QueryError) Error() string {
+	return e.Msg
+}
+
+// queryTerm is one predicate of a query
+type queryTerm struct {
+	negate bool
+	match  func(r model.Repo) bool
+	isText bool
+	text   string // plain text fuzzy matched by matchText
+}
+
+// textMatch is how a text term matched a repo
+type textMatch struct {
+	score  int
+	name   []int // matched rune indexes of the name
+	branch []int // matched rune indexes of the branch
+}
+
+// Match reports whether the repo satisfies every term of the query
+func (q *Query) Match(r model.Repo) bool {
+	for _, t := range q.terms {
+		ok := false
+		if t.isText {
+			_, ok = q.matchText(r, t.text)
+		} else {
+			ok = t.match(r)
+		}
+		if ok == t.negate {
+			return false
+		}
+	}
+	return true
+}
+
+// Score returns how well the repo matches the text of the query; higher
+// is better. It is 0 for a query without text.
+func (q *Query) Score(r model.Repo) int {
+	score := 0
+	for _, t := range q.terms {
+		if t.isText && !t.negate {
+			m, _ := q.matchText(r, t.text)
+			score += m.score
+		}
+	}
+	return score
+}
+
+// Highlights returns the rune indexes of the repo name and branch matched
+// by the text of the query, in ascending order
+func (q *Query) Highlights(r model.Repo) (name, branch []int) {
+	for _, t := range q.terms {
+		if t.isText && !t.negate {
+			m, _ := q.matchText(r, t.text)
+			name = append(name, m.name...)
+			branch = append(branch, m.branch...)
+		}
+	}
+	return uniqueInts(name), uniqueInts(branch)
+}
+
+// Rank returns repos ordered by Score, best first. Repos that score the
+// same keep their order.
+func (q *Query) Rank(repos []model.Repo) []model.Repo {
+	scores := make(map[string]int, len(repos))
+	for _, r := range repos {
+		scores[r.Path] = q.Score(r)
+	}
+	ranked := make([]model.Repo, len(repos))
+	copy(ranked, repos)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return scores[ranked[i].Path] > scores[ranked[j].Path]
+	})
+	return ranked
+}
+
+// matchText fuzzy matches text against the name and branch of the repo,
+// falling back to its path relative to the roots when neither matches.
+// The path only matches at word starts, since short text would match
+// the letters of almost any path. The score is the best of the matches;
+// the name gets a small bonus since that is what is usually searched for.
+func (q *Query) matchText(r model.Repo, text string) (textMatch, bool) {
+	var m textMatch
+	found := false
+	if score, pos, ok := FuzzyMatch(text, r.Name); ok {
+		m.score, m.name, found = score+fuzzyBoundary, pos, true
+	}
+	if score, pos, ok := FuzzyMatch(text, r.Status.Branch); ok {
+		m.branch = pos
+		if !found || score > m.score {
+			m.score = score
+		}
+		found = true
+	}
+	if found {
+		return m, true
+	}
+	if rel := q.relativePath(r.Path); rel != "" {
+		if score, _, ok := wordMatch(text, rel); ok {
+			m.score = score
+			return m, true
+		}
+	}
+	return m, false
+}
+
+// relativePath returns p relative to the longest root containing it, or
+// "" when no root does
+func (q *Query) relativePath(p string) string {
+	_, rel, _ := containingRoot(q.Roots, p)
+	return rel
+}
+
+// containingRoot returns the longest of roots that p lies below, as it
+// was given, along with p relative to it
+func containingRoot(roots []string, p string) (root, rel string, ok bool) {
+	longest := -1
+	for _, r := range roots {
+		abs, err := filepath.Abs(expandHome(r))
+		if err != nil {
+			continue
+		}
+		prefix := abs + string(filepath.Separator)
+		if strings.HasPrefix(p, prefix) && len(abs) > longest {
+			root, rel, longest = r, filepath.ToSlash(p[len(prefix):]), len(abs)
+		}
+	}
+	return root, rel, longest >= 0
+}
+
+// uniqueInts sorts ints and drops duplicates
+func uniqueInts(ints []int) []int {
+	if len(ints) == 0 {
+		return nil
+	}
+	sort.Ints(ints)
+	unique := ints[:1]
+	for _, n := range ints[1:] {
+		if n != unique[len(unique)-1] {
+			unique = append(unique, n)
+		}
+	}
+	return unique
+}
+
+// String returns the query as it was written
+func (q *Query) String() string {
+	return q.source
+}
+
+// Text returns the plain text terms of the query that are not negated
+func (q *Query) Text() []string {
+	var text []string
+	for _, t := range q.terms {
+		if t.isText && t.text != "" && !t.negate {
+			text = append(text, t.text)
+		}
+	}
+	return text
+}
+
+// queryKeywords are the bare words that select repos by state
+var queryKeywords = map[string]func(s model.RepoStatus) bool{
+	"dirty":       func(s model.RepoStatus) bool { return s.IsDirty },
+	"clean":       func(s model.RepoStatus) bool { return !s.IsDirty && s.ScanError == "" },
+	"ahead":       func(s model.RepoStatus) bool { return s.Ahead > 0 },
+	"unpushed":    func(s model.RepoStatus) bool { return s.Ahead > 0 },
+	"behind":      func(s model.RepoStatus) bool { return s.Behind > 0 },
+	"stashed":     func(s model.RepoStatus) bool { return s.Stashes > 0 },
+	"conflicted":  func(s model.RepoStatus) bool { return s.Conflicts > 0 },
+	"in-progress": func(s model.RepoStatus) bool { return s.IsInProgress() },
+	"no-upstream": func(s model.RepoStatus) bool { return s.Tracking == model.TrackingNoUpstream },
+	"gone":        func(s model.RepoStatus) bool { return s.Tracking == model.TrackingGone },
+	"detached":    func(s model.RepoStatus) bool { return s.Tracking == model.TrackingDetached },
+	"errored":     func(s model.RepoStatus) bool { return s.ScanError != "" },
+	"stale": func(s model.RepoStatus) bool {
+		return !s.LastCommit.IsZero() && time.Since(s.LastCommit) > StaleAge
+	},
+}
+
+// queryStrings are the string fields of a repo that can be matched
+var queryStrings = map[string]func(r model.Repo) string{
+	"name":     func(r model.Repo) string { return r.Name },
+	"path":     func(r model.Repo) string { return r.Path },
+	"kind":     func(r model.Repo) string { return string(r.Kind) },
+	"branch":   func(r model.Repo) string { return r.Status.Branch },
+	"upstream": func(r model.Repo) string { return r.Status.Upstream },
+	"head":     func(r model.Repo) string { return r.Status.HeadOID },
+	"tracking": func(r model.Repo) string { return string(r.Status.Tracking) },
+	"op":       func(r model.Repo) string { return string(r.Status.Operation) },
+	"error":    func(r model.Repo) string { return r.Status.ScanError },
+}
+
+// queryNumbers are the counts of a repo that can be compared
+var queryNumbers = map[string]func(s model.RepoStatus) int{
+	"ahead":     func(s model.RepoStatus) int { return s.Ahead },
+	"behind":    func(s model.RepoStatus) int { return s.Behind },
+	"staged":    func(s model.RepoStatus) int { return s.Staged },
+	"unstaged":  func(s model.RepoStatus) int { return s.Unstaged },
+	"modified":  func(s model.RepoStatus) int { return s.Unstaged },
+	"untracked": func(s model.RepoStatus) int { return s.Untracked },
+	"conflicts": func(s model.RepoStatus) int { return s.Conflicts },
+	"stashes":   func(s model.RepoStatus) int { return s.Stashes },
+}
+
+// queryOperators are the comparison operators, longest first so that <=
+// is not read as <
+var queryOperators = []string{"!=", "<=", ">=", ":", "=", "<", ">"}
+
+// ParseQuery parses a search query. An empty query matches every repo.
+// A query that does not parse returns a *QueryError.
+func ParseQuery(s string) (*Query, error) {
+	tokens, err := tokenizeQuery(s)
+	if err != nil {
+		return nil, err
+	}
+
+	q := &Query{source: s}
+	for _, tok := range tokens {
+		t, err := parseQueryTerm(tok)
+		if err != nil {
+			return nil, &QueryError{Start: tok.start, End: tok.end, Msg: err.Error()}
+		}
+		q.terms = append(q.terms, t)
+	}
+	return q, nil
+}
+
+// queryToken is a space-separated word of a query with quotes removed
+type queryToken struct {
+	text       string
+	negate     bool
+	quoted     bool // the token starts with a quote, so it is plain text
+	start, end int  // byte range in the query
+}
+
+// tokenizeQuery splits a query at spaces outside of quotes
+func tokenizeQuery(s string) ([]queryToken, error) {
+	var tokens []queryToken
+	var cur strings.Builder
+	var quote rune
+	start, quoteStart := -1, 0
+
+	flush := func(end int) {
+		if start >= 0 {
+			tok := queryToken{text: cur.String(), start: start, end: end}
+			// A leading - outside quotes negates the rest of the token
+			if s[start] == '-' && len(tok.text) > 1 {
+				tok.negate = true
+				tok.text = tok.text[1:]
+				start++
+			}
+			tok.quoted = isQuote(s[start])
+			tokens = append(tokens, tok)
+		}
+		cur.Reset()
+		start = -1
+	}
+
+	for i, c := range s {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else {
+				cur.WriteRune(c)
+			}
+		case c < 0x80 && isQuote(byte(c)):
+			if start < 0 {
+				start = i
+			}
+			quote, quoteStart = c, i
+		case unicode.IsSpace(c):
+			flush(i)
+		default:
+			if start < 0 {
+				start = i
+			}
+			cur.WriteRune(c)
+		}
+	}
+	if quote != 0 {
+		return nil, &QueryError{Start: quoteStart, End: len(s), Msg: "unterminated quote"}
+	}
+	flush(len(s))
+	return tokens, nil
+}
+
+// isQuote reports whether c opens a quoted string
+func isQuote(c byte) bool {
+	return c == '"' || c == '\''
+}
+
+// parseQueryTerm parses one token into a term
+func parseQueryTerm(tok queryToken) (queryTerm, error) {
+	text := tok.text
+	t := queryTerm{negate: tok.negate}
+	if tok.quoted {
+		return textTerm(t, text), nil
+	}
+
+	field, op, value, ok := splitPredicate(text)
+	if !ok {
+		if is, found := queryKeywords[strings.ToLower(text)]; found {
+			t.match = func(r model.Repo) bool { return is(r.Status) }
+			return t, nil
+		}
+		return textTerm(t, text), nil
+	}
+
+	if value == "" {
+		return t, fmt.Errorf("%s%s needs a value", field, op)
+	}
+	var err error
+	switch {
+	case queryNumbers[field] != nil:
+		t.match, err = numberPredicate(field, op, value)
+	case queryStrings[field] != nil:
+		t.match, err = stringPredicate(field, op, value)
+	case field == "age":
+		t.match, err = agePredicate(op, value)
+	default:
+		err = fmt.Errorf("unknown field %q (try %s)", field, strings.Join(queryFields(), ", "))
+	}
+	return t, err
+}
+
+// textTerm makes t a plain text term, see Query.matchText
+func textTerm(t queryTerm, text string) queryTerm {
+	t.isText = true
+	t.text = text
+	return t
+}
+
+// splitPredicate splits "field<op>value", reporting false if text does
+// not start with a field name followed by an operator
+func splitPredicate(text string) (field, op, value string, ok bool) {
+	i := 0
+	for i < len(text) && (text[i] >= 'a' && text[i] <= 'z' || text[i] >= 'A' && text[i] <= 'Z' || text[i] == '-') {
+		i++
+	}
+	if i == 0 {
+		return "", "", "", false
+	}
+	for _, op := range queryOperators {
+		if strings.HasPrefix(text[i:], op) {
+			return strings.ToLower(text[:i]), op, text[i+len(op):], true
+		}
+	}
+	return "", "", "", false
+}
+
+// queryFields lists the field names, for error messages
+func queryFields() []string {
+	fields := []string{"age"}
+	for f := range queryStrings {
+		fields = append(fields, f)
+	}
+	for f := range queryNumbers {
+		if f != "modified" {
+			fields = append(fields, f)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// numberPredicate compares a count of the repo with value
+func numberPredicate(field, op, value string) (func(model.Repo) bool, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %q is not a number", field, value)
+	}
+	get := queryNumbers[field]
+	cmp := compareOp(op)
+	return func(r model.Repo) bool { return cmp(get(r.Status), n) }, nil
+}
+
+// agePredicate compares the time since the last commit with value, a
+// duration such as 90m, 12h, 7d or 2w. A repo without commits is older
+// than any age.
+func agePredicate(op, value string) (func(model.Repo) bool, error) {
+	age, err := parseAge(value)
+	if err != nil {
+		return nil, fmt.Errorf("age: %v", err)
+	}
+	if op != "<" && op != "<=" && op != ">" && op != ">=" {
+		return nil, fmt.Errorf("age: use <, <=, > or >=")
+	}
+	older := op == ">" || op == ">="
+	return func(r model.Repo) bool {
+		if r.Status.LastCommit.IsZero() {
+			return older
+		}
+		return time.Since(r.Status.LastCommit) > age == older
+	}, nil
+}
+
+// parseAge parses a duration with a unit of m, h, d or w
+func parseAge(s string) (time.Duration, error) {
+	units := map[byte]time.Duration{
+		'm': time.Minute,
+		'h': time.Hour,
+		'd': 24 * time.Hour,
+		'w': 7 * 24 * time.Hour,
+	}
+	unit, ok := units[s[len(s)-1]]
+	if !ok {
+		return 0, fmt.Errorf("%q needs a unit: m, h, d or w", s)
+	}
+	n, err := strconv.Atoi(s[:len(s)-1])
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%q is not a duration like 7d", s)
+	}
+	return time.Duration(n) * unit, nil
+}
+
+// compareOp returns the comparison for an operator; : means =
+func compareOp(op string) func(a, b int) bool {
+	switch op {
+	case "!=":
+		return func(a, b int) bool { return a != b }
+	case "<":
+		return func(a, b int) bool { return a < b }
+	case "<=":
+		return func(a, b int) bool { return a <= b }
+	case ">":
+		return func(a, b int) bool { return a > b }
+	case ">=":
+		return func(a, b int) bool { return a >= b }
+	}
+	return func(a, b int) bool { return a == b }
+}
+
+// stringPredicate matches a string field of the repo against value: a
+// substring, a glob or a /regexp/. != negates the match.
+func stringPredicate(field, op, value string) (func(model.Repo) bool, error) {
+	if op != ":" && op != "=" && op != "!=" {
+		return nil, fmt.Errorf("%s: use : or != to match text", field)
+	}
+	if field == "path" {
+		value = expandHome(value)
+	}
+
+	var matches func(s string) bool
+	switch {
+	case len(value) >= 2 && strings.HasPrefix(value, "/") && strings.HasSuffix(value, "/") && field != "path":
+		re, err := regexp.Compile("(?i)" + value[1:len(value)-1])
+		if err != nil {
+			return nil, fmt.Errorf("%s: bad regexp: %v", field, err)
+		}
+		matches = re.MatchString
+	case strings.ContainsAny(value, "*?["):
+		pattern := strings.ToLower(value)
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("%s: bad pattern %q", field, value)
+		}
+		matches = func(s string) bool {
+			ok, _ := path.Match(pattern, strings.ToLower(s))
+			return ok
+		}
+	default:
+		lower := strings.ToLower(value)
+		matches = func(s string) bool { return strings.Contains(strings.ToLower(s), lower) }
+	}
+
+	get := queryStrings[field]
+	negate := op == "!="
+	return func(r model.Repo) bool { return matches(get(r)) != negate }, nil
+}
+
+// expandHome expands a leading ~ to the home directory
+func expandHome(p string) string {
+	if p != "~" && !strings.HasPrefix(p, "~/") {
+		return p
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return p
+	}
+	return home + p[1:]
+}
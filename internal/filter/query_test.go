@@ -0,0 +1,205 @@
+package filter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Bharath-code/git-scope/internal/model"
+)
+
+// queryRepos are the repos the query tests match against
+func queryRepos() []model.Repo {
+	now := time.Now()
+	return []model.Repo{
+		{
+			Name: "git-scope",
+			Path: "/work/git-scope",
+			Status: model.RepoStatus{
+				Branch:     "feat/query",
+				IsDirty:    true,
+				Unstaged:   3,
+				Ahead:      2,
+				LastCommit: now.Add(-24 * time.Hour),
+			},
+		},
+		{
+			Name: "api-gateway",
+			Path: "/work/api-gateway",
+			Status: model.RepoStatus{
+				Branch:     "main",
+				Behind:     1,
+				Stashes:    1,
+				LastCommit: now.Add(-60 * 24 * time.Hour),
+			},
+		},
+		{
+			Name:   "docs",
+			Path:   "/home/docs",
+			Status: model.RepoStatus{Branch: "feat-x"},
+		},
+	}
+}
+
+func TestQueryMatch(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"", []string{"git-scope", "api-gateway", "docs"}},
+		{"dirty", []string{"git-scope"}},
+		{"DIRTY", []string{"git-scope"}},
+		{"-dirty", []string{"api-gateway", "docs"}},
+		{"stale", []string{"api-gateway"}},
+		{"-stale", []string{"git-scope", "docs"}},
+		{"stashed", []string{"api-gateway"}},
+		{"dirty ahead>0", []string{"git-scope"}},
+		{"-dirty -stale", []string{"docs"}},
+
+		// Numbers
+		{"ahead>0", []string{"git-scope"}},
+		{"ahead>=2", []string{"git-scope"}},
+		{"ahead<2", []string{"api-gateway", "docs"}},
+		{"ahead<=2", []string{"git-scope", "api-gateway", "docs"}},
+		{"ahead=2", []string{"git-scope"}},
+		{"ahead:2", []string{"git-scope"}},
+		{"ahead!=2", []string{"api-gateway", "docs"}},
+		{"behind:1", []string{"api-gateway"}},
+		{"modified>0", []string{"git-scope"}},
+		{"-ahead>0", []string{"api-gateway", "docs"}},
+
+		// Strings: substrings, globs and regexps
+		{"name:api", []string{"api-gateway"}},
+		{"name:API", []string{"api-gateway"}},
+		{"branch:feat/*", []string{"git-scope"}},
+		{"branch:feat*", []string{"docs"}}, // * does not cross /
+		{"branch:feat?query", nil},
+		{"branch:/^feat/", []string{"git-scope", "docs"}},
+		{"branch:/^FEAT-/", []string{"docs"}},
+		{"branch!=main", []string{"git-scope", "docs"}},
+		{"-branch:main", []string{"git-scope", "docs"}},
+		{"path:/work", []string{"git-scope", "api-gateway"}},
+		{"path:/work/*", []string{"git-scope", "api-gateway"}},
+
+		// Ages; a repo without commits is older than any age
+		{"age<7d", []string{"git-scope"}},
+		{"age<=2d", []string{"git-scope"}},
+		{"age>7d", []string{"api-gateway", "docs"}},
+		{"age>=90d", []string{"docs"}},
+		{"age<12h", nil},
+		{"age<2w", []string{"git-scope"}},
+		{"age<90m", nil},
+
+		// Quoted text is plain text, never a keyword or field
+		{`"dirty"`, nil},
+		{`'branch:main'`, nil},
+		{`-"dirty"`, []string{"git-scope", "api-gateway", "docs"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, err := ParseQuery(tt.query)
+			if err != nil {
+				t.Fatalf("ParseQuery(%q): %v", tt.query, err)
+			}
+			var got []string
+			for _, r := range queryRepos() {
+				if q.Match(r) {
+					got = append(got, r.Name)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseQuery(%q) matched %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryText(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"", nil},
+		{"gsc", []string{"gsc"}},
+		{"dirty gsc api", []string{"gsc", "api"}},
+		{"-gsc api", []string{"api"}},
+		{`"two words" x`, []string{"two words", "x"}},
+		{`'it''s'`, []string{"its"}},
+		{`"dirty"`, []string{"dirty"}},
+		{`"-dirty"`, []string{"-dirty"}},
+		{`-"dirty"`, nil},
+		{`pre"quoted part"post`, []string{"prequoted partpost"}},
+		{"-", []string{"-"}},
+		{"  spaced\tout  ", []string{"spaced", "out"}},
+	}
+
+	for _, tt := range tests {
+		q, err := ParseQuery(tt.query)
+		if err != nil {
+			t.Fatalf("ParseQuery(%q): %v", tt.query, err)
+		}
+		if got := q.Text(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseQuery(%q).Text() = %q, want %q", tt.query, got, tt.want)
+		}
+		if got := q.String(); got != tt.query {
+			t.Errorf("ParseQuery(%q).String() = %q", tt.query, got)
+		}
+	}
+}
+
+func TestQueryErrors(t *testing.T) {
+	tests := []struct {
+		query      string
+		msg        string
+		start, end int
+	}{
+		{`"abc`, "unterminated quote", 0, 4},
+		{`dirty 'x`, "unterminated quote", 6, 8},
+		{`"done" "open`, "unterminated quote", 7, 12},
+		{"ahead>", "ahead> needs a value", 0, 6},
+		{"dirty branch:", "branch: needs a value", 6, 13},
+		{"ahead>x", `ahead: "x" is not a number`, 0, 7},
+		{"-ahead>x", `ahead: "x" is not a number`, 0, 8},
+		{"stashes:1.5", `stashes: "1.5" is not a number`, 0, 11},
+		{"age<7", `age: "7" needs a unit: m, h, d or w`, 0, 5},
+		{"age<xd", `age: "xd" is not a duration like 7d`, 0, 6},
+		{"age<-1d", `age: "-1d" is not a duration like 7d`, 0, 7},
+		{"age:7d", "age: use <, <=, > or >=", 0, 6},
+		{"age!=7d", "age: use <, <=, > or >=", 0, 7},
+		{"branch>main", "branch: use : or != to match text", 0, 11},
+		{"name<=x", "name: use : or != to match text", 0, 7},
+		{"name:[", `name: bad pattern "["`, 0, 6},
+		{"clean branch:/(/", "branch: bad regexp: error parsing regexp: missing closing ): `(?i)(`", 6, 16},
+		{
+			"dirty color:red",
+			`unknown field "color" (try age, ahead, behind, branch, conflicts, error, head, kind, name, op, path, staged, stashes, tracking, unstaged, untracked, upstream)`,
+			6, 15,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, err := ParseQuery(tt.query)
+			if err == nil {
+				t.Fatalf("ParseQuery(%q) = %v, want an error", tt.query, q)
+			}
+			qe, ok := err.(*QueryError)
+			if !ok {
+				t.Fatalf("ParseQuery(%q) error is %T, want *QueryError", tt.query, err)
+			}
+			if qe.Msg != tt.msg {
+				t.Errorf("ParseQuery(%q) error = %q, want %q", tt.query, qe.Msg, tt.msg)
+			}
+			if qe.Start != tt.start || qe.End != tt.end {
+				t.Errorf("ParseQuery(%q) error range = %d-%d (%q), want %d-%d (%q)",
+					tt.query, qe.Start, qe.End, tt.query[qe.Start:qe.End],
+					tt.start, tt.end, tt.query[tt.start:tt.end])
+			}
+			if !strings.Contains(err.Error(), tt.msg) {
+				t.Errorf("ParseQuery(%q).Error() = %q", tt.query, err.Error())
+			}
+		})
+	}
+}
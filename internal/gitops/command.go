@@ -0,0 +1,49 @@
+package gitops
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"time"
+
+	"github.com/Bharath-code/git-scope/internal/model"
+)
+
+// CommandOp returns an Op that runs argv with the repo as working
+// directory. A single argument is a shell command line; more are run
+// directly. Combined stdout and stderr go to the writer newOutput returns
+// for the repo, which is closed once the command exits. The command gets
+// no stdin, and GIT_SCOPE_REPO and GIT_SCOPE_PATH describe the repo.
+func CommandOp(argv []string, newOutput func(repo model.Repo) io.WriteCloser) Op {
+	return func(ctx context.Context, repo model.Repo) (model.Repo, error) {
+		out := newOutput(repo)
+		defer out.Close()
+
+		cmd := command(ctx, argv)
+		cmd.Dir = repo.Path
+		cmd.Env = append(os.Environ(),
+			"GIT_SCOPE_REPO="+repo.Name,
+			"GIT_SCOPE_PATH="+repo.Path,
+		)
+		cmd.Stdout = out
+		cmd.Stderr = out
+		// Don't wait forever on children that keep output open after the
+		// command is killed
+		cmd.WaitDelay = time.Second
+		return repo, cmd.Run()
+	}
+}
+
+// command builds the command for argv, running a lone argument through
+// the platform shell so pipes and globs work
+func command(ctx context.Context, argv []string) *exec.Cmd {
+	if len(argv) == 1 {
+		if runtime.GOOS == "windows" {
+			return exec.CommandContext(ctx, "cmd", "/C", argv[0])
+		}
+		return exec.CommandContext(ctx, "sh", "-c", argv[0])
+	}
+	return exec.CommandContext(ctx, argv[0], argv[1:]...)
+}
@@ -0,0 +1,181 @@
+// Package gitops runs operations that change repositories, such as git
+// fetch or a user command, across many repos at once on a bounded pool of
+// workers.
+package gitops
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Bharath-code/git-scope/internal/model"
+	"github.com/Bharath-code/git-scope/internal/scan"
+)
+
+const (
+	// DefaultConcurrency is the number of repos operated on at once.
+	// Network operations mostly wait, so this exceeds the CPU count.
+	DefaultConcurrency = 8
+	// DefaultTimeout bounds a single network operation on one repo
+	DefaultTimeout = 2 * time.Minute
+	// NoTimeout lets an operation run for as long as it takes
+	NoTimeout time.Duration = -1
+)
+
+// Result is the outcome of an operation on one repo
+type Result struct {
+	Repo    model.Repo
+	Err     error
+	Elapsed time.Duration
+}
+
+// Op is an operation run on a single repo. It returns the repo, possibly
+// with a refreshed status, and an error if the operation failed.
+type Op func(ctx context.Context, repo model.Repo) (model.Repo, error)
+
+// Options controls how an operation is run across repos
+type Options struct {
+	// Concurrency is the maximum number of repos operated on at the same
+	// time. Zero or less uses DefaultConcurrency.
+	Concurrency int
+	// Timeout bounds the operation on a single repo. Zero uses
+	// DefaultTimeout; NoTimeout disables the limit.
+	Timeout time.Duration
+}
+
+// Run applies op to every repo on a bounded pool of workers and reports
+// each result on the returned channel as soon as it is known. The channel
+// is closed once all repos are done or ctx is cancelled; callers must
+// drain it.
+func Run(ctx context.Context, repos []model.Repo, op Op, opts Options) <-chan Result {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+	timeout := opts.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+
+	results := make(chan Result)
+	jobs := make(chan model.Repo)
+
+	go func() {
+		defer close(jobs)
+		for _, repo := range repos {
+			select {
+			case jobs <- repo:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for repo := range jobs {
+				res := runOne(ctx, repo, op, timeout)
+				select {
+				case results <- res:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	return results
+}
+
+// runOne applies op to a single repo within the timeout
+func runOne(ctx context.Context, repo model.Repo, op Op, timeout time.Duration) Result {
+	start := time.Now()
+	var opCtx context.Context
+	var cancel context.CancelFunc
+	if timeout > 0 {
+		opCtx, cancel = context.WithTimeout(ctx, timeout)
+	} else {
+		opCtx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+
+	updated, err := op(opCtx, repo)
+	if errors.Is(opCtx.Err(), context.DeadlineExceeded) {
+		err = errors.New("timed out")
+	}
+	return Result{Repo: updated, Err: err, Elapsed: time.Since(start)}
+}
+
+// Fetch downloads objects and refs from all remotes of the repo, pruning
+// remote-tracking branches that were deleted upstream
+func Fetch(ctx context.Context, repo model.Repo) error {
+	_, err := git(ctx, repo.Path, "fetch", "--all", "--prune", "--quiet")
+	return err
+}
+
+// FetchOp returns an Op that fetches a repo and then re-reads its status,
+// so ahead/behind counts reflect the remotes
+func FetchOp(scanOpts scan.Options) Op {
+	return func(ctx context.Context, repo model.Repo) (model.Repo, error) {
+		if err := Fetch(ctx, repo); err != nil {
+			return repo, err
+		}
+		return scan.RefreshRepo(ctx, repo, scanOpts), nil
+	}
+}
+
+// git runs a git command in dir and returns its trimmed stdout. git and
+// ssh are never allowed to prompt, so missing credentials fail fast
+// instead of hanging. On failure the error is the reason git gave on stderr.
+func git(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_TERMINAL_PROMPT=0",
+		"GCM_INTERACTIVE=never",
+		"SSH_ASKPASS_REQUIRE=never",
+	)
+	if os.Getenv("GIT_SSH_COMMAND") == "" && os.Getenv("GIT_SSH") == "" {
+		cmd.Env = append(cmd.Env, "GIT_SSH_COMMAND=ssh -o BatchMode=yes")
+	}
+	// Don't wait forever on children that keep stdout open after git is killed
+	cmd.WaitDelay = time.Second
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := errorLine(stderr.String()); msg != "" {
+			return "", errors.New(msg)
+		}
+		return "", err
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// errorLine picks the line of git's stderr that explains a failure: the
+// first "fatal:" or "error:" line, or else the last line
+func errorLine(stderr string) string {
+	lines := strings.Split(strings.TrimSpace(stderr), "\n")
+	for _, prefix := range []string{"fatal: ", "error: "} {
+		for _, line := range lines {
+			if strings.HasPrefix(line, prefix) {
+				return strings.TrimPrefix(line, prefix)
+			}
+		}
+	}
+	return strings.TrimSpace(lines[len(lines)-1])
+}
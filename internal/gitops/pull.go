@@ -0,0 +1,69 @@
+package gitops
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Bharath-code/git-scope/internal/model"
+	"github.com/Bharath-code/git-scope/internal/scan"
+)
+
+// SkipError is returned by an Op that left a repo alone on purpose, such
+// as a pull of a repo with local changes
+type SkipError struct {
+	Reason string
+}
+
+func (e *SkipError) Error() string {
+	return "skipped: " + e.Reason
+}
+
+// PullBlocker returns why a repo with this status cannot be fast-forwarded
+// safely, or "" if it can: it must be clean, on a branch that tracks a live
+// upstream, with nothing in progress, and strictly behind.
+func PullBlocker(s model.RepoStatus) string {
+	switch {
+	case s.ScanError != "":
+		return "status unknown"
+	case s.Operation != "":
+		return "mid-" + string(s.Operation)
+	case s.Conflicts > 0:
+		return "conflicts"
+	case s.Tracking == model.TrackingDetached:
+		return "detached HEAD"
+	case s.Tracking == model.TrackingNoUpstream:
+		return "no upstream"
+	case s.Tracking == model.TrackingGone:
+		return "upstream gone"
+	case s.IsDirty:
+		return "dirty"
+	case s.Ahead > 0 && s.Behind > 0:
+		return "diverged"
+	case s.Ahead > 0:
+		return "ahead"
+	case s.Behind == 0:
+		return "up to date"
+	}
+	return ""
+}
+
+// PullOp returns an Op that fast-forwards a repo to its upstream. The
+// status is re-read first and the repo is skipped with a SkipError if
+// PullBlocker objects, so a stale listing never leads to a bad merge.
+// The returned repo carries the status after the pull.
+func PullOp(scanOpts scan.Options) Op {
+	return func(ctx context.Context, repo model.Repo) (model.Repo, error) {
+		if reason := PullBlocker(repo.Status); reason != "" {
+			return repo, &SkipError{Reason: reason}
+		}
+		repo = scan.RefreshRepo(ctx, repo, scanOpts)
+		if reason := PullBlocker(repo.Status); reason != "" {
+			return repo, &SkipError{Reason: reason}
+		}
+
+		if _, err := git(ctx, repo.Path, "merge", "--ff-only", "--quiet", "@{upstream}"); err != nil {
+			return repo, fmt.Errorf("fast-forward: %w", err)
+		}
+		return scan.RefreshRepo(ctx, repo, scanOpts), nil
+	}
+}
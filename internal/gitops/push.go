@@ -0,0 +1,109 @@
+package gitops
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Bharath-code/git-scope/internal/model"
+	"github.com/Bharath-code/git-scope/internal/scan"
+)
+
+// PushBlocker returns why the current branch of a repo with this status
+// cannot be pushed safely, or "" if it can: it must be a branch with
+// commits to push that tracks a live upstream it has not diverged from,
+// or has no upstream yet.
+func PushBlocker(s model.RepoStatus) string {
+	switch {
+	case s.ScanError != "":
+		return "status unknown"
+	case s.Tracking == model.TrackingDetached || s.Branch == "":
+		return "detached HEAD"
+	case s.Tracking == model.TrackingGone:
+		return "upstream gone"
+	case s.Ahead == 0:
+		return "nothing to push"
+	case s.Behind > 0:
+		return "diverged"
+	}
+	return ""
+}
+
+// PushOp returns an Op that pushes the current branch of a repo to its
+// upstream, naming the remote and upstream branch explicitly so that
+// push.default and remote.pushDefault cannot send it elsewhere. A branch
+// without upstream is pushed to a branch of the same
+// name on origin, or on the only remote, and set to track it. The status
+// is re-read first and the repo is skipped with a SkipError if PushBlocker
+// objects. The returned repo carries the status after the push.
+func PushOp(scanOpts scan.Options) Op {
+	return func(ctx context.Context, repo model.Repo) (model.Repo, error) {
+		if reason := PushBlocker(repo.Status); reason != "" {
+			return repo, &SkipError{Reason: reason}
+		}
+		repo = scan.RefreshRepo(ctx, repo, scanOpts)
+		if reason := PushBlocker(repo.Status); reason != "" {
+			return repo, &SkipError{Reason: reason}
+		}
+
+		args := []string{"push", "--quiet"}
+		if repo.Status.Tracking == model.TrackingNoUpstream {
+			remote, err := defaultRemote(ctx, repo.Path)
+			if err != nil {
+				return repo, err
+			}
+			args = append(args, "--set-upstream", remote, "HEAD")
+		} else {
+			remote, ref, err := upstreamRef(ctx, repo.Path, repo.Status.Branch)
+			if err != nil {
+				return repo, err
+			}
+			args = append(args, remote, "HEAD:"+ref)
+		}
+		if _, err := git(ctx, repo.Path, args...); err != nil {
+			return repo, fmt.Errorf("push: %w", err)
+		}
+		return scan.RefreshRepo(ctx, repo, scanOpts), nil
+	}
+}
+
+// upstreamRef returns the remote and the full ref of the branch on it
+// that branch tracks, e.g. "origin" and "refs/heads/main". A branch
+// tracking another local branch is skipped with a SkipError.
+func upstreamRef(ctx context.Context, dir, branch string) (remote, ref string, err error) {
+	remote, err = git(ctx, dir, "config", "--get", "branch."+branch+".remote")
+	if err != nil {
+		return "", "", fmt.Errorf("read upstream remote: %w", err)
+	}
+	// Pushing to "." would move another local branch instead
+	if remote = strings.TrimSpace(remote); remote == "." {
+		return "", "", &SkipError{Reason: "upstream is a local branch"}
+	}
+	ref, err = git(ctx, dir, "config", "--get", "branch."+branch+".merge")
+	if err != nil {
+		return "", "", fmt.Errorf("read upstream branch: %w", err)
+	}
+	return remote, strings.TrimSpace(ref), nil
+}
+
+// defaultRemote returns the remote a branch without upstream is pushed
+// to: origin if it exists, or else the only remote
+func defaultRemote(ctx context.Context, dir string) (string, error) {
+	out, err := git(ctx, dir, "remote")
+	if err != nil {
+		return "", fmt.Errorf("list remotes: %w", err)
+	}
+	remotes := strings.Fields(out)
+	for _, r := range remotes {
+		if r == "origin" {
+			return r, nil
+		}
+	}
+	switch len(remotes) {
+	case 0:
+		return "", &SkipError{Reason: "no remote"}
+	case 1:
+		return remotes[0], nil
+	}
+	return "", &SkipError{Reason: "no origin remote"}
+}
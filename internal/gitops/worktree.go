@@ -0,0 +1,60 @@
+package gitops
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// Stage adds the given paths of the repo at dir to the index, including
+// deletions and untracked files
+func Stage(ctx context.Context, dir string, paths ...string) error {
+	_, err := git(ctx, dir, append([]string{"add", "--all", "--"}, paths...)...)
+	return err
+}
+
+// StageAll adds every change in the working tree to the index
+func StageAll(ctx context.Context, dir string) error {
+	_, err := git(ctx, dir, "add", "--all")
+	return err
+}
+
+// Unstage removes the given paths from the index, keeping the working
+// tree changes. It also works before the first commit.
+func Unstage(ctx context.Context, dir string, paths ...string) error {
+	_, err := git(ctx, dir, append([]string{"reset", "--quiet", "--"}, paths...)...)
+	return err
+}
+
+// UnstageAll removes every staged change from the index
+func UnstageAll(ctx context.Context, dir string) error {
+	_, err := git(ctx, dir, "reset", "--quiet")
+	return err
+}
+
+// Commit records the staged changes with message
+func Commit(ctx context.Context, dir, message string) error {
+	if strings.TrimSpace(message) == "" {
+		return errors.New("empty commit message")
+	}
+	_, err := git(ctx, dir, "commit", "--quiet", "--message", message)
+	return err
+}
+
+// Stash saves the local changes to a new stash entry, with message if it
+// is not empty
+func Stash(ctx context.Context, dir, message string) error {
+	args := []string{"stash", "push", "--quiet"}
+	if message = strings.TrimSpace(message); message != "" {
+		args = append(args, "--message", message)
+	}
+	_, err := git(ctx, dir, args...)
+	return err
+}
+
+// StashPop applies the latest stash entry and drops it. On a conflict the
+// entry is kept and an error is returned.
+func StashPop(ctx context.Context, dir string) error {
+	_, err := git(ctx, dir, "stash", "pop", "--quiet")
+	return err
+}
@@ -0,0 +1,194 @@
+package gitstatus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Bharath-code/git-scope/internal/model"
+)
+
+// DefaultCommitCount is how many recent commits Details lists
+const DefaultCommitCount = 20
+
+// Details collects the changed files, local branches, recent commits and
+// stashes of the repository at repoPath. A repo without commits yields
+// empty commit and branch lists rather than an error.
+func Details(ctx context.Context, repoPath string, commits int) (model.RepoDetails, error) {
+	var d model.RepoDetails
+
+	files, err := ChangedFiles(ctx, repoPath)
+	if err != nil {
+		return d, err
+	}
+	d.Files = files
+
+	if d.Branches, err = localBranches(ctx, repoPath); err != nil {
+		return d, err
+	}
+	// Fails on a repo without commits, which simply has none to list
+	d.Commits, _ = recentCommits(ctx, repoPath, commits)
+	if d.Stashes, err = stashList(ctx, repoPath); err != nil {
+		return d, err
+	}
+	return d, nil
+}
+
+// ChangedFiles lists the porcelain status entries of the repository at
+// repoPath. Untracked directories are listed file by file, except nested
+// repositories, which git still lists as one directory entry.
+func ChangedFiles(ctx context.Context, repoPath string) ([]model.FileChange, error) {
+	out, err := runGit(ctx, repoPath, "status", "--porcelain", "-z", "--untracked-files=all")
+	if err != nil {
+		return nil, fmt.Errorf("git status: %w", err)
+	}
+
+	var files []model.FileChange
+	entries := strings.Split(string(out), "\x00")
+	for i := 0; i < len(entries); i++ {
+		entry := entries[i]
+		if len(entry) < 4 {
+			continue
+		}
+		f := model.FileChange{Index: entry[0], Worktree: entry[1], Path: entry[3:]}
+		// Renames and copies are followed by their source path
+		if (f.Index == 'R' || f.Index == 'C') && i+1 < len(entries) {
+			i++
+			f.OrigPath = entries[i]
+		}
+		files = append(files, f)
+	}
+	return files, nil
+}
+
+// localBranches lists local branches, most recently committed first
+func localBranches(ctx context.Context, repoPath string) ([]model.BranchInfo, error) {
+	out, err := runGit(ctx, repoPath, "for-each-ref", "--sort=-committerdate",
+		"--format=%(HEAD)%00%(refname:short)%00%(upstream:short)%00%(upstream:track,nobracket)",
+		"refs/heads")
+	if err != nil {
+		return nil, fmt.Errorf("git for-each-ref: %w", err)
+	}
+
+	var branches []model.BranchInfo
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		fields := strings.Split(line, "\x00")
+		if len(fields) != 4 {
+			continue
+		}
+		b := model.BranchInfo{
+			Current:  fields[0] == "*",
+			Name:     fields[1],
+			Upstream: fields[2],
+		}
+		b.Ahead, b.Behind, b.Gone = parseTrack(fields[3])
+		branches = append(branches, b)
+	}
+	return branches, nil
+}
+
+// parseTrack parses %(upstream:track,nobracket), e.g. "ahead 1, behind 2"
+// or "gone"
+func parseTrack(track string) (ahead, behind int, gone bool) {
+	if track == "gone" {
+		return 0, 0, true
+	}
+	for _, part := range strings.Split(track, ", ") {
+		if n, ok := strings.CutPrefix(part, "ahead "); ok {
+			ahead, _ = strconv.Atoi(n)
+		} else if n, ok := strings.CutPrefix(part, "behind "); ok {
+			behind, _ = strconv.Atoi(n)
+		}
+	}
+	return ahead, behind, false
+}
+
+// recentCommits lists the last n commits reachable from HEAD
+func recentCommits(ctx context.Context, repoPath string, n int) ([]model.CommitInfo, error) {
+	out, err := runGit(ctx, repoPath, "log", "-n", strconv.Itoa(n), "--format=%h%x00%s%x00%an%x00%ct")
+	if err != nil {
+		return nil, fmt.Errorf("git log: %w", err)
+	}
+
+	var commits []model.CommitInfo
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		fields := strings.Split(line, "\x00")
+		if len(fields) != 4 {
+			continue
+		}
+		c := model.CommitInfo{Hash: fields[0], Subject: fields[1], Author: fields[2]}
+		if sec, err := strconv.ParseInt(fields[3], 10, 64); err == nil {
+			c.When = time.Unix(sec, 0)
+		}
+		commits = append(commits, c)
+	}
+	return commits, nil
+}
+
+// stashList lists the stash entries, newest first
+func stashList(ctx context.Context, repoPath string) ([]model.StashInfo, error) {
+	out, err := runGit(ctx, repoPath, "stash", "list", "--format=%gd%x00%s")
+	if err != nil {
+		return nil, fmt.Errorf("git stash list: %w", err)
+	}
+
+	var stashes []model.StashInfo
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		ref, subject, ok := strings.Cut(line, "\x00")
+		if !ok {
+			continue
+		}
+		stashes = append(stashes, model.StashInfo{Ref: ref, Subject: subject})
+	}
+	return stashes, nil
+}
+
+// maxDiffLines caps the diff returned by FileDiff so a huge generated
+// file cannot stall the UI
+const maxDiffLines = 5000
+
+// FileDiff returns the diff of one changed file: the staged changes, or
+// the working tree changes against the index. Untracked files diff
+// against nothing; untracked directories, such as nested repositories,
+// have no diff and are an error.
+func FileDiff(ctx context.Context, repoPath string, file model.FileChange, staged bool) (string, error) {
+	var out []byte
+	var err error
+	switch {
+	case file.IsUntracked() && strings.HasSuffix(file.Path, "/"):
+		return "", fmt.Errorf("%s is a directory, likely a nested repository, and has no diff", file.Path)
+	case file.IsUntracked():
+		if staged {
+			return "", nil
+		}
+		// --no-index exits 1 when the files differ, which they always do
+		out, err = runGit(ctx, repoPath, "diff", "--no-color", "--no-index", "--", os.DevNull, file.Path)
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			err = nil
+		}
+	case staged:
+		args := []string{"diff", "--no-color", "--cached", "-M", "--"}
+		if file.OrigPath != "" {
+			args = append(args, file.OrigPath)
+		}
+		out, err = runGit(ctx, repoPath, append(args, file.Path)...)
+	default:
+		out, err = runGit(ctx, repoPath, "diff", "--no-color", "--", file.Path)
+	}
+	if err != nil {
+		return "", fmt.Errorf("git diff: %w", err)
+	}
+
+	diff := string(out)
+	if lines := strings.SplitAfter(diff, "\n"); len(lines) > maxDiffLines {
+		diff = strings.Join(lines[:maxDiffLines], "") +
+			fmt.Sprintf("\n… diff truncated, %d more lines\n", len(lines)-maxDiffLines)
+	}
+	return diff, nil
+}
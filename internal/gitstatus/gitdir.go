@@ -0,0 +1,64 @@
+package gitstatus
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Bharath-code/git-scope/internal/model"
+)
+
+// ResolveGitDir inspects the .git entry of the checkout at repoPath and
+// reports its git dir, the common git dir shared by all of its worktrees
+// and what kind of checkout it is. A .git directory is a main checkout;
+// a .git file ("gitdir: <path>") is either a linked worktree, when the
+// target git dir has a `commondir` file, or a submodule.
+func ResolveGitDir(repoPath string) (gitDir, commonDir string, kind model.RepoKind, err error) {
+	dotGit := filepath.Join(repoPath, ".git")
+
+	info, err := os.Stat(dotGit)
+	if err != nil {
+		return "", "", "", err
+	}
+	if info.IsDir() {
+		return dotGit, dotGit, model.KindMain, nil
+	}
+
+	gitDir, err = readGitFile(dotGit)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	data, err := os.ReadFile(filepath.Join(gitDir, "commondir"))
+	if err != nil {
+		// No commondir: the git dir is self-contained (submodule)
+		return gitDir, gitDir, model.KindSubmodule, nil
+	}
+
+	commonDir = strings.TrimSpace(string(data))
+	if !filepath.IsAbs(commonDir) {
+		commonDir = filepath.Join(gitDir, commonDir)
+	}
+	return gitDir, filepath.Clean(commonDir), model.KindWorktree, nil
+}
+
+// readGitFile parses a `gitdir: <path>` .git file and returns the
+// absolute git dir it points to
+func readGitFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	line := strings.TrimSpace(string(data))
+	if !strings.HasPrefix(line, "gitdir:") {
+		return "", fmt.Errorf("invalid .git file: %s", path)
+	}
+
+	gitDir := strings.TrimSpace(strings.TrimPrefix(line, "gitdir:"))
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(filepath.Dir(path), gitDir)
+	}
+	return filepath.Clean(gitDir), nil
+}
@@ -0,0 +1,67 @@
+package model
+
+import "time"
+
+// FileChange is one path in the porcelain status of a repository
+type FileChange struct {
+	Path     string `json:"path"`
+	OrigPath string `json:"orig_path,omitempty"` // source of a rename or copy
+	// Index and Worktree are the porcelain XY status letters, e.g. 'M',
+	// 'A', 'D', 'R', '?' (untracked) or ' ' (unchanged)
+	Index    byte `json:"index"`
+	Worktree byte `json:"worktree"`
+}
+
+// IsStaged reports whether the change is in the index
+func (f FileChange) IsStaged() bool {
+	return f.Index != ' ' && f.Index != '?' && !f.IsConflict()
+}
+
+// IsUnstaged reports whether the worktree differs from the index
+func (f FileChange) IsUnstaged() bool {
+	return f.Worktree != ' ' && f.Worktree != '?' && !f.IsConflict()
+}
+
+// IsUntracked reports whether the path is not tracked by git
+func (f FileChange) IsUntracked() bool {
+	return f.Index == '?'
+}
+
+// IsConflict reports whether the path has unresolved merge conflicts
+func (f FileChange) IsConflict() bool {
+	return f.Index == 'U' || f.Worktree == 'U' ||
+		(f.Index == 'A' && f.Worktree == 'A') || (f.Index == 'D' && f.Worktree == 'D')
+}
+
+// BranchInfo describes a local branch and its upstream
+type BranchInfo struct {
+	Name     string `json:"name"`
+	Upstream string `json:"upstream,omitempty"`
+	Ahead    int    `json:"ahead"`
+	Behind   int    `json:"behind"`
+	Gone     bool   `json:"gone,omitempty"` // the upstream no longer exists
+	Current  bool   `json:"current,omitempty"`
+}
+
+// CommitInfo is a one-line summary of a commit
+type CommitInfo struct {
+	Hash    string    `json:"hash"`
+	Subject string    `json:"subject"`
+	Author  string    `json:"author"`
+	When    time.Time `json:"when"`
+}
+
+// StashInfo is an entry of the stash list
+type StashInfo struct {
+	Ref     string `json:"ref"` // e.g. stash@{0}
+	Subject string `json:"subject"`
+}
+
+// RepoDetails is the in-depth state of a single repository: what changed,
+// which branches exist and what was committed recently
+type RepoDetails struct {
+	Files    []FileChange `json:"files"`
+	Branches []BranchInfo `json:"branches"`
+	Commits  []CommitInfo `json:"commits"`
+	Stashes  []StashInfo  `json:"stashes"`
+}
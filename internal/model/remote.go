@@ -0,0 +1,64 @@
+package model
+
+import (
+	"net/url"
+	"strings"
+)
+
+// RemoteLocation splits a remote URL into the host and the repository
+// path without a .git suffix, e.g. "git@github.com:owner/repo.git" into
+// "github.com" and "owner/repo". Local remotes have an empty host.
+func RemoteLocation(remote string) (host, path string) {
+	remote = strings.TrimSpace(remote)
+	if remote == "" {
+		return "", ""
+	}
+
+	switch {
+	case strings.Contains(remote, "://"):
+		u, err := url.Parse(remote)
+		if err != nil {
+			return "", trimRepoPath(remote)
+		}
+		if u.Scheme == "file" {
+			return "", trimRepoPath(u.Path)
+		}
+		return u.Hostname(), trimRepoPath(u.Path)
+	case isSCPLike(remote):
+		// [user@]host:path
+		i := strings.Index(remote, ":")
+		host = remote[:i]
+		if at := strings.LastIndex(host, "@"); at >= 0 {
+			host = host[at+1:]
+		}
+		return host, trimRepoPath(remote[i+1:])
+	}
+	return "", trimRepoPath(remote)
+}
+
+// RemoteSlug shortens a remote URL to host/path, e.g.
+// "github.com/owner/repo"
+func RemoteSlug(remote string) string {
+	host, path := RemoteLocation(remote)
+	if host == "" {
+		return path
+	}
+	return host + "/" + path
+}
+
+// isSCPLike reports whether remote is written like scp, e.g.
+// git@host:path, rather than as a local path
+func isSCPLike(remote string) bool {
+	colon := strings.Index(remote, ":")
+	slash := strings.Index(remote, "/")
+	// A colon after the first slash is part of a local path, and a
+	// single letter before it is a Windows drive
+	return colon > 1 && (slash < 0 || colon < slash)
+}
+
+// trimRepoPath drops the slashes around a repository path and its .git
+// suffix
+func trimRepoPath(p string) string {
+	p = strings.Trim(p, "/")
+	return strings.TrimSuffix(p, ".git")
+}
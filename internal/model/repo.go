@@ -2,22 +2,96 @@ package model
 
 import "time"
 
+// RepoKind describes how a checkout is attached to its git directory
+type RepoKind string
+
+const (
+	// KindMain is a regular checkout with its own .git directory
+	KindMain RepoKind = "main"
+	// KindWorktree is a linked worktree created by `git worktree add`
+	KindWorktree RepoKind = "worktree"
+	// KindSubmodule is a submodule checkout whose .git file points into
+	// the superproject's modules directory
+	KindSubmodule RepoKind = "submodule"
+)
+
+// Operation is a multi-step git operation left in progress in a repository
+type Operation string
+
+const (
+	OpRebase     Operation = "rebase"
+	OpAm         Operation = "am"
+	OpMerge      Operation = "merge"
+	OpCherryPick Operation = "cherry-pick"
+	OpRevert     Operation = "revert"
+	OpBisect     Operation = "bisect"
+)
+
+// TrackingState describes how the checked-out HEAD relates to an upstream
+type TrackingState string
+
+const (
+	// TrackingOK means the branch tracks an upstream that exists
+	TrackingOK TrackingState = "tracking"
+	// TrackingNoUpstream means the branch has no upstream configured
+	TrackingNoUpstream TrackingState = "no-upstream"
+	// TrackingGone means the configured upstream branch no longer exists
+	TrackingGone TrackingState = "gone"
+	// TrackingDetached means HEAD is not on a branch
+	TrackingDetached TrackingState = "detached"
+)
+
 // RepoStatus contains the git status information for a repository
 type RepoStatus struct {
-	Branch     string    `json:"branch"`
-	Ahead      int       `json:"ahead"`
-	Behind     int       `json:"behind"`
-	Staged     int       `json:"staged"`
-	Unstaged   int       `json:"unstaged"`
-	Untracked  int       `json:"untracked"`
-	LastCommit time.Time `json:"last_commit"`
-	IsDirty    bool      `json:"is_dirty"`
-	ScanError  string    `json:"scan_error,omitempty"`
+	Branch     string        `json:"branch"`
+	Upstream   string        `json:"upstream,omitempty"`
+	HeadOID    string        `json:"head_oid,omitempty"`
+	Tracking   TrackingState `json:"tracking"`
+	Ahead      int           `json:"ahead"`
+	Behind     int           `json:"behind"`
+	Staged     int           `json:"staged"`
+	Unstaged   int           `json:"unstaged"`
+	Untracked  int           `json:"untracked"`
+	Conflicts  int           `json:"conflicts"`
+	Stashes    int           `json:"stashes"`
+	Operation  Operation     `json:"operation,omitempty"`
+	LastCommit time.Time     `json:"last_commit"`
+	// LastAuthor and LastMessage are the author name and subject of the
+	// latest commit
+	LastAuthor  string `json:"last_author,omitempty"`
+	LastMessage string `json:"last_message,omitempty"`
+	// Remote is the URL of the upstream's remote, or of origin
+	Remote    string `json:"remote,omitempty"`
+	IsDirty   bool   `json:"is_dirty"`
+	ScanError string `json:"scan_error,omitempty"`
 }
 
 // Repo represents a git repository with its metadata and status
 type Repo struct {
-	Name   string     `json:"name"`
-	Path   string     `json:"path"`
-	Status RepoStatus `json:"status"`
+	Name      string     `json:"name"`
+	Path      string     `json:"path"`
+	Kind      RepoKind   `json:"kind"`
+	GitDir    string     `json:"git_dir"`
+	CommonDir string     `json:"common_dir"`
+	Status    RepoStatus `json:"status"`
+	// Fingerprint of the git dir state when Status was collected, used
+	// to tell whether a cached status is still current
+	Fingerprint string `json:"-"`
+}
+
+// IsInProgress reports whether an operation was left unfinished or files
+// have unresolved conflicts
+func (s RepoStatus) IsInProgress() bool {
+	return s.Operation != "" || s.Conflicts > 0
+}
+
+// HasUnpushedWork reports whether HEAD has commits that exist on no remote
+// and there is no live upstream they would be pushed to
+func (s RepoStatus) HasUnpushedWork() bool {
+	return (s.Tracking == TrackingNoUpstream || s.Tracking == TrackingGone) && s.Ahead > 0
+}
+
+// IsWorktree reports whether the repo is a linked worktree of another checkout
+func (r Repo) IsWorktree() bool {
+	return r.Kind == KindWorktree
 }
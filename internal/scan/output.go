@@ -0,0 +1,201 @@
+package scan
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+	"time"
+
+	"github.com/Bharath-code/git-scope/internal/model"
+)
+
+// Output formats accepted by Print
+const (
+	FormatJSON     = "json"
+	FormatNDJSON   = "ndjson"
+	FormatTable    = "table"
+	FormatCSV      = "csv"
+	FormatMarkdown = "markdown"
+)
+
+// Formats lists the named output formats, for help texts
+var Formats = []string{FormatJSON, FormatNDJSON, FormatTable, FormatCSV, FormatMarkdown}
+
+// outputColumns are the fields written by the table, CSV and Markdown formats
+var outputColumns = []string{
+	"name", "branch", "staged", "modified", "untracked", "stashes",
+	"ahead", "behind", "state", "last_commit", "path",
+}
+
+// Printer writes repos in one output format
+type Printer func(w io.Writer, repos []model.Repo) error
+
+// NewPrinter returns the printer for format, which is either one of
+// Formats or a text/template executed once per repo
+// (e.g. "{{.Name}} {{.Status.Branch}}")
+func NewPrinter(format string) (Printer, error) {
+	switch format {
+	case "", FormatJSON:
+		return PrintJSON, nil
+	case FormatNDJSON:
+		return PrintNDJSON, nil
+	case FormatTable:
+		return PrintTable, nil
+	case FormatCSV:
+		return PrintCSV, nil
+	case FormatMarkdown, "md":
+		return PrintMarkdown, nil
+	}
+
+	if !strings.Contains(format, "{{") {
+		return nil, fmt.Errorf("unknown format %q (want %s or a Go template)",
+			format, strings.Join(Formats, ", "))
+	}
+	tmpl, err := template.New("format").Parse(format)
+	if err != nil {
+		return nil, fmt.Errorf("parse format template: %w", err)
+	}
+	return func(w io.Writer, repos []model.Repo) error {
+		return printTemplate(w, tmpl, repos)
+	}, nil
+}
+
+// PrintJSON outputs the repos as formatted JSON
+func PrintJSON(w io.Writer, repos []model.Repo) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(repos); err != nil {
+		return fmt.Errorf("encode json: %w", err)
+	}
+	return nil
+}
+
+// PrintNDJSON outputs one compact JSON object per line
+func PrintNDJSON(w io.Writer, repos []model.Repo) error {
+	enc := json.NewEncoder(w)
+	for _, r := range repos {
+		if err := enc.Encode(r); err != nil {
+			return fmt.Errorf("encode json: %w", err)
+		}
+	}
+	return nil
+}
+
+// PrintTable outputs an aligned plain-text table
+func PrintTable(w io.Writer, repos []model.Repo) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.ToUpper(strings.Join(outputColumns, "\t")))
+	for _, r := range repos {
+		fields := outputFields(r)
+		fields[len(fields)-2] = formatCommitTime(r.Status.LastCommit, "2006-01-02 15:04")
+		fmt.Fprintln(tw, strings.Join(fields, "\t"))
+	}
+	return tw.Flush()
+}
+
+// PrintCSV outputs a CSV document with a header row
+func PrintCSV(w io.Writer, repos []model.Repo) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(outputColumns); err != nil {
+		return fmt.Errorf("write csv: %w", err)
+	}
+	for _, r := range repos {
+		if err := cw.Write(outputFields(r)); err != nil {
+			return fmt.Errorf("write csv: %w", err)
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// PrintMarkdown outputs a GitHub-flavored Markdown table
+func PrintMarkdown(w io.Writer, repos []model.Repo) error {
+	header := make([]string, len(outputColumns))
+	rule := make([]string, len(outputColumns))
+	for i, col := range outputColumns {
+		header[i] = strings.ReplaceAll(col, "_", " ")
+		rule[i] = "---"
+	}
+	if _, err := fmt.Fprintf(w, "| %s |\n| %s |\n", strings.Join(header, " | "), strings.Join(rule, " | ")); err != nil {
+		return err
+	}
+	for _, r := range repos {
+		fields := outputFields(r)
+		fields[len(fields)-2] = formatCommitTime(r.Status.LastCommit, "2006-01-02 15:04")
+		for i, f := range fields {
+			fields[i] = strings.ReplaceAll(f, "|", `\|`)
+		}
+		fields[0] = "**" + fields[0] + "**"
+		fields[len(fields)-1] = "`" + fields[len(fields)-1] + "`"
+		if _, err := fmt.Fprintf(w, "| %s |\n", strings.Join(fields, " | ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// printTemplate executes tmpl for every repo, ending each with a newline
+// unless the template already does
+func printTemplate(w io.Writer, tmpl *template.Template, repos []model.Repo) error {
+	var sb strings.Builder
+	for _, r := range repos {
+		sb.Reset()
+		if err := tmpl.Execute(&sb, r); err != nil {
+			return fmt.Errorf("execute format template: %w", err)
+		}
+		if !strings.HasSuffix(sb.String(), "\n") {
+			sb.WriteByte('\n')
+		}
+		if _, err := io.WriteString(w, sb.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// outputFields returns the values of outputColumns for a repo
+func outputFields(r model.Repo) []string {
+	s := r.Status
+	return []string{
+		r.Name,
+		s.Branch,
+		strconv.Itoa(s.Staged),
+		strconv.Itoa(s.Unstaged),
+		strconv.Itoa(s.Untracked),
+		strconv.Itoa(s.Stashes),
+		strconv.Itoa(s.Ahead),
+		strconv.Itoa(s.Behind),
+		repoState(s),
+		formatCommitTime(s.LastCommit, time.RFC3339),
+		r.Path,
+	}
+}
+
+// repoState summarizes a status in one word for tabular output
+func repoState(s model.RepoStatus) string {
+	switch {
+	case s.ScanError != "":
+		return "error"
+	case s.Operation != "":
+		return string(s.Operation)
+	case s.Conflicts > 0:
+		return "conflict"
+	case s.IsDirty:
+		return "dirty"
+	default:
+		return "clean"
+	}
+}
+
+// formatCommitTime formats t, leaving repos without commits blank
+func formatCommitTime(t time.Time, layout string) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Local().Format(layout)
+}
@@ -1,14 +1,18 @@
 package scan
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
-	"io"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/Bharath-code/git-scope/internal/cache"
+	"github.com/Bharath-code/git-scope/internal/config"
 	"github.com/Bharath-code/git-scope/internal/gitstatus"
 	"github.com/Bharath-code/git-scope/internal/model"
 )
@@ -31,24 +35,171 @@ var smartIgnorePatterns = []string{
 	"Google Drive", "OneDrive", "Dropbox", "iCloud",
 }
 
+// DefaultTimeout bounds how long collecting the status of a single repo
+// may take before it is recorded as timed out
+const DefaultTimeout = 10 * time.Second
+
+// Options controls how repository status is collected during a scan
+type Options struct {
+	// Concurrency is the maximum number of repos whose status is collected
+	// at the same time. Zero or less uses runtime.NumCPU().
+	Concurrency int
+	// Timeout bounds the status collection of a single repo. Zero or less
+	// uses DefaultTimeout.
+	Timeout time.Duration
+	// Reuse, if set, is asked for a known status of each discovered repo
+	// before git is run. The repo passed in carries a fresh Fingerprint.
+	// Returning ok = true skips the costly parts of the query: the last
+	// commit, unpushed count and remote are taken from the known status,
+	// while the working tree is always queried.
+	Reuse func(repo model.Repo) (status model.RepoStatus, ok bool)
+}
+
+// OptionsFromConfig returns the scan options configured in cfg
+func OptionsFromConfig(cfg *config.Config) Options {
+	return Options{
+		Concurrency: cfg.ScanConcurrency,
+		Timeout:     cfg.ScanTimeout,
+	}
+}
+
+// concurrency returns the effective worker count
+func (o Options) concurrency() int {
+	if o.Concurrency > 0 {
+		return o.Concurrency
+	}
+	return runtime.NumCPU()
+}
+
+// timeout returns the effective per-repo timeout
+func (o Options) timeout() time.Duration {
+	if o.Timeout > 0 {
+		return o.Timeout
+	}
+	return DefaultTimeout
+}
+
 // ScanRoots recursively scans the given root directories for git repositories
 // It skips directories matching the ignore patterns
 func ScanRoots(roots, ignore []string) ([]model.Repo, error) {
-	// Build ignore set from user config + smart defaults
-	ignoreSet := make(map[string]struct{}, len(ignore)+len(smartIgnorePatterns))
+	return ScanRootsContext(context.Background(), roots, ignore, Options{})
+}
 
-	// Add user-defined ignores
-	for _, pattern := range ignore {
-		ignoreSet[pattern] = struct{}{}
+// ScanRootsContext is like ScanRoots but collects repository status on a
+// bounded pool of workers, gives up on a single repo after the configured
+// timeout, and aborts the whole scan when ctx is cancelled.
+func ScanRootsContext(ctx context.Context, roots, ignore []string, opts Options) ([]model.Repo, error) {
+	var repos []model.Repo
+	for ev := range Stream(ctx, roots, ignore, opts) {
+		if ev.Type == EventStatus {
+			repos = append(repos, ev.Repo)
+		}
 	}
 
-	// Add smart defaults (always apply for performance)
-	for _, pattern := range smartIgnorePatterns {
-		ignoreSet[pattern] = struct{}{}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return repos, nil
+}
+
+// EventType identifies the stage of a repo reported by Stream
+type EventType int
+
+const (
+	// EventFound is sent when a checkout is discovered, before its status
+	// has been collected
+	EventFound EventType = iota
+	// EventStatus is sent once the status of a discovered repo is known
+	EventStatus
+)
+
+// Event reports progress of a streaming scan
+type Event struct {
+	Type EventType
+	Repo model.Repo
+}
+
+// Stream scans like ScanRootsContext but reports every repo on the
+// returned channel twice: as soon as it is discovered (EventFound) and
+// again once its status is collected (EventStatus). Discovery never waits
+// for status collection. The channel is closed when the scan finishes or
+// ctx is cancelled; callers must drain it.
+func Stream(ctx context.Context, roots, ignore []string, opts Options) <-chan Event {
+	events := make(chan Event)
+	found := make(chan model.Repo)
+	jobs := make(chan model.Repo)
+
+	send := func(ev Event) {
+		select {
+		case events <- ev:
+		case <-ctx.Done():
+		}
 	}
 
-	var mu sync.Mutex
-	var repos []model.Repo
+	go func() {
+		discover(ctx, roots, ignore, found)
+		close(found)
+	}()
+
+	var wg sync.WaitGroup
+
+	// Queue discovered repos for the workers so a slow git status never
+	// holds up the walk
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(jobs)
+
+		var queue []model.Repo
+		in := found
+		for in != nil || len(queue) > 0 {
+			var out chan model.Repo
+			var next model.Repo
+			if len(queue) > 0 {
+				out = jobs
+				next = queue[0]
+			}
+
+			select {
+			case repo, ok := <-in:
+				if !ok {
+					in = nil
+					continue
+				}
+				queue = append(queue, repo)
+				send(Event{Type: EventFound, Repo: repo})
+			case out <- next:
+				queue = queue[1:]
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for i := 0; i < opts.concurrency(); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for repo := range jobs {
+				send(Event{Type: EventStatus, Repo: collectStatus(ctx, repo, opts)})
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(events)
+	}()
+
+	return events
+}
+
+// discover walks the roots and sends every checkout it finds to found,
+// without collecting status. It returns once all roots are walked or ctx
+// is cancelled.
+func discover(ctx context.Context, roots, ignore []string, found chan<- model.Repo) {
+	isIgnored := IgnoreFunc(ignore)
+
 	var wg sync.WaitGroup
 
 	for _, root := range roots {
@@ -64,18 +215,22 @@ func ScanRoots(roots, ignore []string) ([]model.Repo, error) {
 		go func(r string) {
 			defer wg.Done()
 			err := filepath.WalkDir(r, func(path string, d os.DirEntry, err error) error {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
 				if err != nil {
 					// Skip directories we can't access
 					return nil
 				}
 
 				// Skip ignored directories
-				if d.IsDir() && shouldIgnore(d.Name(), ignoreSet) {
+				if d.IsDir() && isIgnored(d.Name()) {
 					return filepath.SkipDir
 				}
 
-				// Found a .git directory
-				if d.IsDir() && d.Name() == ".git" {
+				// Found a .git directory, or a .git file (linked worktree
+				// or submodule checkout)
+				if d.Name() == ".git" && (d.IsDir() || d.Type().IsRegular()) {
 					repoPath := filepath.Dir(path)
 
 					// Resolve to absolute path to get proper repo name
@@ -84,30 +239,24 @@ func ScanRoots(roots, ignore []string) ([]model.Repo, error) {
 					if err == nil {
 						repoPath = absPath
 					}
-					repoName := filepath.Base(repoPath)
-
-					status, serr := gitstatus.Status(repoPath)
 
-					repo := model.Repo{
-						Name:   repoName,
-						Path:   repoPath,
-						Status: status,
-					}
-					if serr != nil {
-						repo.Status.ScanError = serr.Error()
+					if repo, ok := newRepo(repoPath); ok {
+						select {
+						case found <- repo:
+						case <-ctx.Done():
+							return ctx.Err()
+						}
 					}
 
-					mu.Lock()
-					repos = append(repos, repo)
-					mu.Unlock()
-
 					// Don't walk into .git directory
-					return filepath.SkipDir
+					if d.IsDir() {
+						return filepath.SkipDir
+					}
 				}
 
 				return nil
 			})
-			if err != nil {
+			if err != nil && ctx.Err() == nil {
 				// Log but don't fail
 				fmt.Fprintf(os.Stderr, "warning: scan error in %s: %v\n", r, err)
 			}
@@ -115,7 +264,86 @@ func ScanRoots(roots, ignore []string) ([]model.Repo, error) {
 	}
 
 	wg.Wait()
-	return repos, nil
+}
+
+// newRepo builds a Repo for the checkout at repoPath without its status.
+// It returns ok = false if the .git entry is not a usable git dir
+// (e.g. a stray file named .git).
+func newRepo(repoPath string) (model.Repo, bool) {
+	gitDir, commonDir, kind, err := gitstatus.ResolveGitDir(repoPath)
+	if err != nil {
+		return model.Repo{}, false
+	}
+
+	return model.Repo{
+		Name:      filepath.Base(repoPath),
+		Path:      repoPath,
+		Kind:      kind,
+		GitDir:    gitDir,
+		CommonDir: commonDir,
+	}, true
+}
+
+// collectStatus fills in the status of a discovered repo, recording a
+// ScanError instead of blocking when git takes longer than the timeout.
+// The fingerprint is taken first, so changes made while git runs are
+// never hidden behind it.
+func collectStatus(ctx context.Context, repo model.Repo, opts Options) model.Repo {
+	repo.Fingerprint = cache.Fingerprint(repo)
+
+	repoCtx, cancel := context.WithTimeout(ctx, opts.timeout())
+	defer cancel()
+
+	var status model.RepoStatus
+	var err error
+	if prev, ok := reuse(repo, opts); ok {
+		status, err = gitstatus.RefreshContext(repoCtx, repo.Path, prev)
+	} else {
+		status, err = gitstatus.StatusContext(repoCtx, repo.Path)
+	}
+	repo.Status = status
+	switch {
+	case errors.Is(repoCtx.Err(), context.DeadlineExceeded):
+		repo.Status.ScanError = "timed out"
+	case err != nil:
+		repo.Status.ScanError = err.Error()
+	}
+	return repo
+}
+
+// reuse asks opts.Reuse for a known status of repo
+func reuse(repo model.Repo, opts Options) (model.RepoStatus, bool) {
+	if opts.Reuse == nil {
+		return model.RepoStatus{}, false
+	}
+	return opts.Reuse(repo)
+}
+
+// IgnoreFunc returns a function that reports whether a directory name
+// matches the user ignore patterns or the always-ignored smart defaults
+func IgnoreFunc(ignore []string) func(name string) bool {
+	// Build ignore set from user config + smart defaults
+	ignoreSet := make(map[string]struct{}, len(ignore)+len(smartIgnorePatterns))
+
+	// Add user-defined ignores
+	for _, pattern := range ignore {
+		ignoreSet[pattern] = struct{}{}
+	}
+
+	// Add smart defaults (always apply for performance)
+	for _, pattern := range smartIgnorePatterns {
+		ignoreSet[pattern] = struct{}{}
+	}
+
+	return func(name string) bool {
+		return shouldIgnore(name, ignoreSet)
+	}
+}
+
+// RefreshRepo re-queries the status of a single known repo
+func RefreshRepo(ctx context.Context, repo model.Repo, opts Options) model.Repo {
+	opts.Reuse = nil
+	return collectStatus(ctx, repo, opts)
 }
 
 // shouldIgnore checks if a directory name matches any ignore pattern
@@ -145,12 +373,32 @@ func expandPath(path string) string {
 	return os.ExpandEnv(path)
 }
 
-// PrintJSON outputs the repos as formatted JSON
-func PrintJSON(w io.Writer, repos []model.Repo) error {
-	enc := json.NewEncoder(w)
-	enc.SetIndent("", "  ")
-	if err := enc.Encode(repos); err != nil {
-		return fmt.Errorf("encode json: %w", err)
+// GroupWorktrees returns repos reordered so that every linked worktree
+// directly follows the main checkout it shares a common git dir with.
+// Worktrees whose main checkout was not scanned keep their position.
+func GroupWorktrees(repos []model.Repo) []model.Repo {
+	worktrees := make(map[string][]model.Repo)
+	mains := make(map[string]bool)
+	for _, r := range repos {
+		if r.Kind == model.KindMain {
+			mains[r.CommonDir] = true
+		}
+	}
+	for _, r := range repos {
+		if r.IsWorktree() && mains[r.CommonDir] {
+			worktrees[r.CommonDir] = append(worktrees[r.CommonDir], r)
+		}
+	}
+
+	grouped := make([]model.Repo, 0, len(repos))
+	for _, r := range repos {
+		if r.IsWorktree() && mains[r.CommonDir] {
+			continue
+		}
+		grouped = append(grouped, r)
+		if r.Kind == model.KindMain {
+			grouped = append(grouped, worktrees[r.CommonDir]...)
+		}
 	}
-	return nil
+	return grouped
 }
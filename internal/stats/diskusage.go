@@ -41,8 +41,12 @@ func GetDiskUsage(repos []model.Repo) (*DiskUsageData, error) {
 			Path: repo.Path,
 		}
 
-		// Calculate .git size
-		gitPath := filepath.Join(repo.Path, ".git")
+		// Calculate .git size (linked worktrees and submodules keep
+		// their git dir elsewhere)
+		gitPath := repo.GitDir
+		if gitPath == "" {
+			gitPath = filepath.Join(repo.Path, ".git")
+		}
 		gitSize, err := getDirSize(gitPath)
 		if err == nil {
 			usage.GitSize = gitSize
@@ -1,61 +1,206 @@
 package tui
 
 import (
+	"context"
 	"time"
 
 	"github.com/Bharath-code/git-scope/internal/cache"
 	"github.com/Bharath-code/git-scope/internal/config"
+	"github.com/Bharath-code/git-scope/internal/gitops"
 	"github.com/Bharath-code/git-scope/internal/model"
 	"github.com/Bharath-code/git-scope/internal/scan"
+	"github.com/Bharath-code/git-scope/internal/watch"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
-// Cache max age - use cached data if less than 5 minutes old
-const cacheMaxAge = 5 * time.Minute
-
 // Run starts the Bubbletea TUI application
 func Run(cfg *config.Config) error {
 	m := NewModel(cfg)
+	if err := m.startView(cfg.View); err != nil {
+		return err
+	}
 	p := tea.NewProgram(m, tea.WithAltScreen())
-	_, err := p.Run()
+	final, err := p.Run()
+
+	// Abort a scan or bulk operation that may still be running and stop
+	// watching
+	if fm, ok := final.(Model); ok {
+		fm.cancelScan()
+		if fm.cancelOp != nil {
+			fm.cancelOp()
+		}
+		if fm.watcher != nil {
+			fm.watcher.Close()
+		}
+	}
 	return err
 }
 
-// scanReposCmd is a command that scans for repositories
-func scanReposCmd(cfg *config.Config) tea.Cmd {
+// scanBatchWindow is how long scan events are collected into one
+// progress message, so a burst of results causes a single redraw
+const scanBatchWindow = 50 * time.Millisecond
+
+// loadReposCmd shows cached results for the configured roots if there are
+// any, and otherwise starts a fresh streaming scan. Cached results are
+// revalidated by a background scan once they are displayed.
+func loadReposCmd(ctx context.Context, id int, cfg *config.Config) tea.Cmd {
 	return func() tea.Msg {
-		// Try to load from cache first
 		cacheStore := cache.NewFileStore()
-		cached, err := cacheStore.Load()
+		if _, err := cacheStore.Load(); err == nil && cacheStore.CoversRoots(cfg.Roots) {
+			return cachedReposMsg{
+				id:       id,
+				repos:    cacheStore.ReposUnder(cfg.Roots),
+				cachedAt: cacheStore.GetTimestamp(),
+			}
+		}
+
+		return nextScanEvents(id, scan.Stream(ctx, cfg.Roots, cfg.Ignore, scan.OptionsFromConfig(cfg)))
+	}
+}
 
-		if err == nil && cacheStore.IsValid(cacheMaxAge) && cacheStore.IsSameRoots(cfg.Roots) {
-			// Use cached data but trigger background refresh
-			return scanCompleteMsg{
-				repos:     cached.Repos,
-				fromCache: true,
+// scanReposCmd streams a fresh scan of the configured roots. An
+// incremental scan still queries the working tree of every repo, but
+// reuses the cached last commit, unpushed count and remote of repos whose
+// fingerprint has not changed.
+func scanReposCmd(ctx context.Context, id int, cfg *config.Config, incremental bool) tea.Cmd {
+	return func() tea.Msg {
+		opts := scan.OptionsFromConfig(cfg)
+		if incremental {
+			cacheStore := cache.NewFileStore()
+			if _, err := cacheStore.Load(); err == nil {
+				opts.Reuse = cacheStore.Lookup
 			}
 		}
+		return nextScanEvents(id, scan.Stream(ctx, cfg.Roots, cfg.Ignore, opts))
+	}
+}
 
-		// Scan fresh
-		repos, err := scan.ScanRoots(cfg.Roots, cfg.Ignore)
-		if err != nil {
-			return scanErrorMsg{err: err}
+// scanWorkspaceCmd streams a scan of a single workspace path
+func scanWorkspaceCmd(ctx context.Context, id int, workspacePath string, cfg *config.Config) tea.Cmd {
+	return func() tea.Msg {
+		return nextScanEvents(id, scan.Stream(ctx, []string{workspacePath}, cfg.Ignore, scan.OptionsFromConfig(cfg)))
+	}
+}
+
+// waitScanEventsCmd waits for the next batch of events of a running scan
+func waitScanEventsCmd(id int, events <-chan scan.Event) tea.Cmd {
+	return func() tea.Msg {
+		return nextScanEvents(id, events)
+	}
+}
+
+// nextScanEvents blocks until the next scan event arrives and batches the
+// events that follow within scanBatchWindow
+func nextScanEvents(id int, events <-chan scan.Event) scanProgressMsg {
+	msg := scanProgressMsg{id: id, ch: events}
+
+	ev, ok := <-events
+	if !ok {
+		msg.done = true
+		return msg
+	}
+	msg.events = append(msg.events, ev)
+
+	window := time.After(scanBatchWindow)
+	for {
+		select {
+		case ev, ok := <-events:
+			if !ok {
+				msg.done = true
+				return msg
+			}
+			msg.events = append(msg.events, ev)
+		case <-window:
+			return msg
 		}
+	}
+}
 
-		// Save to cache
-		_ = cacheStore.Save(repos, cfg.Roots)
+// saveCacheCmd writes scan results to the cache in the background
+func saveCacheCmd(repos []model.Repo, roots []string) tea.Cmd {
+	return func() tea.Msg {
+		_ = cache.NewFileStore().Save(repos, roots)
+		return nil
+	}
+}
+
+// startWatchCmd starts watching repos for changes on disk
+func startWatchCmd(repos []model.Repo, cfg *config.Config) tea.Cmd {
+	return func() tea.Msg {
+		w, err := watch.New(repos, scan.IgnoreFunc(cfg.Ignore), watch.DefaultDebounce)
+		return watchStartedMsg{watcher: w, err: err}
+	}
+}
 
-		return scanCompleteMsg{
-			repos:     repos,
-			fromCache: false,
+// waitWatchCmd waits for the next repo change reported by w
+func waitWatchCmd(w *watch.Watcher) tea.Cmd {
+	return func() tea.Msg {
+		path, ok := <-w.Events()
+		if !ok {
+			return nil
 		}
+		return repoChangedMsg{watcher: w, path: path}
+	}
+}
+
+// refreshRepoCmd re-queries the status of a single repo
+func refreshRepoCmd(repo model.Repo, cfg *config.Config) tea.Cmd {
+	return func() tea.Msg {
+		return repoRefreshedMsg{repo: scan.RefreshRepo(context.Background(), repo, scan.OptionsFromConfig(cfg))}
+	}
+}
+
+// runOpCmd starts a bulk git operation and waits for its first result
+func runOpCmd(ctx context.Context, id int, repos []model.Repo, op gitops.Op) tea.Cmd {
+	return func() tea.Msg {
+		return nextOpResult(id, gitops.Run(ctx, repos, op, gitops.Options{}))
+	}
+}
+
+// waitOpCmd waits for the next result of a running bulk operation
+func waitOpCmd(id int, results <-chan gitops.Result) tea.Cmd {
+	return func() tea.Msg {
+		return nextOpResult(id, results)
 	}
 }
 
-// scanCompleteMsg is sent when scanning is complete
-type scanCompleteMsg struct {
-	repos     []model.Repo
-	fromCache bool
+// nextOpResult blocks until the next result of a bulk operation arrives
+func nextOpResult(id int, results <-chan gitops.Result) opProgressMsg {
+	res, ok := <-results
+	return opProgressMsg{id: id, result: res, ch: results, done: !ok}
+}
+
+// watchStartedMsg is sent when a watcher has been set up
+type watchStartedMsg struct {
+	watcher *watch.Watcher
+	err     error
+}
+
+// repoChangedMsg is sent when a watched repo changed on disk
+type repoChangedMsg struct {
+	watcher *watch.Watcher
+	path    string
+}
+
+// repoRefreshedMsg carries the fresh status of a single repo
+type repoRefreshedMsg struct {
+	repo model.Repo
+}
+
+// scanProgressMsg carries a batch of events from a streaming scan
+type scanProgressMsg struct {
+	id     int
+	events []scan.Event
+	ch     <-chan scan.Event
+	done   bool
+}
+
+// cachedReposMsg is sent when cached results are loaded for display
+// while a fresh scan revalidates them
+type cachedReposMsg struct {
+	id       int
+	repos    []model.Repo
+	cachedAt time.Time
 }
 
 // scanErrorMsg is sent when scanning fails
@@ -63,7 +208,16 @@ type scanErrorMsg struct {
 	err error
 }
 
-// openEditorMsg is sent to trigger opening an editor
+// openEditorMsg is sent to trigger opening repos in the editor
 type openEditorMsg struct {
-	path string
+	paths []string
+}
+
+// opProgressMsg reports one finished repo of a bulk git operation, or
+// that the operation is done
+type opProgressMsg struct {
+	id     int
+	result gitops.Result
+	ch     <-chan gitops.Result
+	done   bool
 }
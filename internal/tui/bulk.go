@@ -0,0 +1,473 @@
+package tui
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Bharath-code/git-scope/internal/gitops"
+	"github.com/Bharath-code/git-scope/internal/model"
+	"github.com/Bharath-code/git-scope/internal/scan"
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// bulkAction is an entry of the bulk-action menu
+type bulkAction struct {
+	key   string
+	label string
+}
+
+// bulkActions lists the actions that apply to the marked repos
+var bulkActions = []bulkAction{
+	{"f", "Fetch"},
+	{"p", "Pull (fast-forward only)"},
+	{"u", "Push…"},
+	{"x", "Run command…"},
+	{"e", "Open in editor"},
+	{"y", "Copy paths"},
+}
+
+// opReport holds the per-repo results of a finished bulk operation for
+// the results view
+type opReport struct {
+	title   string
+	summary string
+	results []gitops.Result
+	output  map[string]string // command output by repo path
+}
+
+// commandOutput collects the output of a command run in many repos
+type commandOutput struct {
+	mu   sync.Mutex
+	bufs map[string]*bytes.Buffer
+}
+
+func newCommandOutput() *commandOutput {
+	return &commandOutput{bufs: make(map[string]*bytes.Buffer)}
+}
+
+// writer returns the writer for the output of one repo
+func (o *commandOutput) writer(repo model.Repo) io.WriteCloser {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	buf := &bytes.Buffer{}
+	o.bufs[repo.Path] = buf
+	return nopCloser{buf}
+}
+
+// strings returns the collected output by repo path
+func (o *commandOutput) strings() map[string]string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	out := make(map[string]string, len(o.bufs))
+	for path, buf := range o.bufs {
+		out[path] = buf.String()
+	}
+	return out
+}
+
+// nopCloser adds a no-op Close to a writer
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error { return nil }
+
+// toggleSelected marks or unmarks the repo under the cursor and moves
+// the cursor down so consecutive rows can be marked quickly
+func (m *Model) toggleSelected() {
+	repo := m.GetSelectedRepo()
+	if repo == nil {
+		return
+	}
+	if m.selected[repo.Path] {
+		delete(m.selected, repo.Path)
+	} else {
+		m.selected[repo.Path] = true
+	}
+	m.updateTable()
+	m.table.MoveDown(1)
+	m.scrollTable()
+}
+
+// toggleSelectAll marks every repo in the current view, or unmarks them
+// if they are all marked already
+func (m *Model) toggleSelectAll() {
+	m.toggleMarks(m.sortedRepos)
+}
+
+// toggleMarks marks repos, or unmarks them if they are all marked already
+func (m *Model) toggleMarks(repos []model.Repo) {
+	all := len(repos) > 0
+	for _, r := range repos {
+		if !m.selected[r.Path] {
+			all = false
+			break
+		}
+	}
+	for _, r := range repos {
+		if all {
+			delete(m.selected, r.Path)
+		} else {
+			m.selected[r.Path] = true
+		}
+	}
+	m.updateTable()
+}
+
+// selectedRepos returns the marked repos in view order, followed by
+// marked repos hidden by the current filter
+func (m Model) selectedRepos() []model.Repo {
+	var repos []model.Repo
+	seen := make(map[string]bool)
+	for _, r := range m.sortedRepos {
+		if m.selected[r.Path] {
+			repos = append(repos, r)
+			seen[r.Path] = true
+		}
+	}
+	for _, r := range m.repos {
+		if m.selected[r.Path] && !seen[r.Path] {
+			repos = append(repos, r)
+		}
+	}
+	return repos
+}
+
+// bulkTargets returns the repos a bulk action applies to: the marked
+// repos, or the repo under the cursor if none are marked
+func (m Model) bulkTargets() []model.Repo {
+	if repos := m.selectedRepos(); len(repos) > 0 {
+		return repos
+	}
+	if repo := m.GetSelectedRepo(); repo != nil {
+		return []model.Repo{*repo}
+	}
+	return nil
+}
+
+// handleBulkMenuMode handles key events while the bulk-action menu is open
+func (m Model) handleBulkMenuMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "b":
+		m.state = StateReady
+		return m, nil
+	case "ctrl+c":
+		return m, tea.Quit
+	case "up", "k":
+		if m.bulkCursor > 0 {
+			m.bulkCursor--
+		}
+		return m, nil
+	case "down", "j":
+		if m.bulkCursor < len(bulkActions)-1 {
+			m.bulkCursor++
+		}
+		return m, nil
+	case "enter":
+		return m.runBulkAction(bulkActions[m.bulkCursor].key)
+	}
+
+	for _, a := range bulkActions {
+		if msg.String() == a.key {
+			return m.runBulkAction(a.key)
+		}
+	}
+	return m, nil
+}
+
+// runBulkAction starts the bulk action with the given menu key on the
+// bulk targets
+func (m Model) runBulkAction(key string) (tea.Model, tea.Cmd) {
+	m.state = StateReady
+	targets := m.bulkTargets()
+	if len(targets) == 0 {
+		return m, nil
+	}
+	if m.opName != "" && (key == "f" || key == "p" || key == "x") {
+		m.statusMsg = fmt.Sprintf("⏳ Wait for the running %s to finish", m.opName)
+		return m, nil
+	}
+
+	switch key {
+	case "f":
+		m.statusMsg = ""
+		cmd := m.startOp("fetch", targets, gitops.FetchOp(scan.OptionsFromConfig(m.cfg)))
+		m.opShowResults = true
+		return m, cmd
+	case "p":
+		m.statusMsg = ""
+		cmd := m.startOp("pull", targets, gitops.PullOp(scan.OptionsFromConfig(m.cfg)))
+		m.opShowResults = true
+		return m, cmd
+	case "u":
+		m.confirmPush(targets)
+		return m, nil
+	case "x":
+		m.state = StateBulkCommand
+		m.commandInput.SetValue("")
+		m.commandInput.Focus()
+		return m, textinput.Blink
+	case "e":
+		paths := make([]string, len(targets))
+		for i, r := range targets {
+			paths[i] = r.Path
+		}
+		m.statusMsg = fmt.Sprintf("Opening %d repos in %s...", len(paths), m.cfg.Editor)
+		return m, func() tea.Msg {
+			return openEditorMsg{paths: paths}
+		}
+	case "y":
+		paths := make([]string, len(targets))
+		for i, r := range targets {
+			paths[i] = r.Path
+		}
+		return m, copyPathsCmd(paths)
+	}
+	return m, nil
+}
+
+// handleBulkCommandMode handles key events while the command to run in
+// the bulk targets is being typed
+func (m Model) handleBulkCommandMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.state = StateReady
+		m.commandInput.Blur()
+		return m, nil
+	case "ctrl+c":
+		return m, tea.Quit
+	case "enter":
+		command := strings.TrimSpace(m.commandInput.Value())
+		m.state = StateReady
+		m.commandInput.Blur()
+		targets := m.bulkTargets()
+		if command == "" || len(targets) == 0 {
+			return m, nil
+		}
+
+		output := newCommandOutput()
+		m.statusMsg = ""
+		cmd := m.startOp("run", targets, gitops.CommandOp([]string{command}, output.writer))
+		m.opShowResults = true
+		m.opCommand = command
+		m.opOutput = output
+		return m, cmd
+	}
+
+	var cmd tea.Cmd
+	m.commandInput, cmd = m.commandInput.Update(msg)
+	return m, cmd
+}
+
+// handleResultsMode handles key events in the results view
+func (m Model) handleResultsMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q", "enter", "R":
+		m.state = StateReady
+		return m, nil
+	case "ctrl+c":
+		return m, tea.Quit
+	}
+
+	var cmd tea.Cmd
+	m.resultsView, cmd = m.resultsView.Update(msg)
+	return m, cmd
+}
+
+// openResults shows the results view for the last finished operation
+func (m *Model) openResults() {
+	if m.lastOp == nil {
+		return
+	}
+	m.state = StateResults
+	m.resizeResults()
+	m.resultsView.SetContent(renderReport(m.lastOp))
+	m.resultsView.GotoTop()
+}
+
+// resizeResults fits the results viewport to the window
+func (m *Model) resizeResults() {
+	m.resultsView.Width = m.width - 4
+	m.resultsView.Height = m.height - 11
+	if m.resultsView.Height < 1 {
+		m.resultsView.Height = 1
+	}
+}
+
+// newReport builds the report of a finished operation, listing results
+// in name order
+func newReport(name, command string, summary string, results []gitops.Result, output *commandOutput) *opReport {
+	sorted := make([]gitops.Result, len(results))
+	copy(sorted, results)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Repo.Name < sorted[j].Repo.Name
+	})
+
+	title := strings.ToUpper(name[:1]) + name[1:]
+	if command != "" {
+		title += ": " + command
+	}
+	report := &opReport{title: title, summary: summary, results: sorted}
+	if output != nil {
+		report.output = output.strings()
+	}
+	return report
+}
+
+// renderReport renders the per-repo lines of a report, with the command
+// output of each repo indented below it
+func renderReport(r *opReport) string {
+	width := 0
+	for _, res := range r.results {
+		if len(res.Repo.Name) > width {
+			width = len(res.Repo.Name)
+		}
+	}
+
+	okStyle := lipgloss.NewStyle().Foreground(cleanColor)
+	skipStyle := lipgloss.NewStyle().Foreground(mutedColor)
+	failStyle := lipgloss.NewStyle().Foreground(errorColor)
+	outputStyle := lipgloss.NewStyle().Foreground(textSecondary)
+
+	var b strings.Builder
+	for _, res := range r.results {
+		var skip *gitops.SkipError
+		mark, detail := okStyle.Render("✓"), ""
+		switch {
+		case errors.As(res.Err, &skip):
+			mark, detail = skipStyle.Render("–"), skipStyle.Render("skipped: "+skip.Reason)
+		case res.Err != nil:
+			mark, detail = failStyle.Render("✗"), failStyle.Render(res.Err.Error())
+		}
+		fmt.Fprintf(&b, "%s %-*s  %5.1fs  %s\n", mark, width, res.Repo.Name, res.Elapsed.Seconds(), detail)
+
+		out := strings.TrimRight(r.output[res.Repo.Path], "\n")
+		if out == "" {
+			continue
+		}
+		for _, line := range strings.Split(out, "\n") {
+			b.WriteString(outputStyle.Render("    │ "+line) + "\n")
+		}
+	}
+	return b.String()
+}
+
+// copyPathsCmd copies repo paths to the system clipboard, one per line
+func copyPathsCmd(paths []string) tea.Cmd {
+	return func() tea.Msg {
+		err := clipboard.WriteAll(strings.Join(paths, "\n"))
+		return pathsCopiedMsg{count: len(paths), err: err}
+	}
+}
+
+// pathsCopiedMsg is sent once repo paths were copied to the clipboard,
+// or with the error when no clipboard is available
+type pathsCopiedMsg struct {
+	count int
+	err   error
+}
+
+// renderBulkMenu renders the bulk-action menu
+func (m Model) renderBulkMenu() string {
+	var b strings.Builder
+
+	b.WriteString(compactLogo())
+	b.WriteString("\n\n")
+
+	modalStyle := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#7C3AED")).
+		Padding(1, 2).
+		Width(50)
+
+	title := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#A78BFA")).
+		Bold(true).
+		Render(fmt.Sprintf("⚡ Bulk actions · %s", targetsLabel(m.bulkTargets())))
+
+	var items strings.Builder
+	for i, a := range bulkActions {
+		line := keyBindingKeyStyle.Render(a.key) + "  " + a.label
+		if i == m.bulkCursor {
+			line = lipgloss.NewStyle().Foreground(primaryDim).Bold(true).Render("▸ ") + line
+		} else {
+			line = "  " + line
+		}
+		items.WriteString(line + "\n")
+	}
+
+	footer := lipgloss.NewStyle().
+		Foreground(mutedColor).
+		Render("\n↑↓ = choose   Enter = run   Esc = cancel")
+
+	b.WriteString(modalStyle.Render(title + "\n\n" + items.String() + footer))
+	b.WriteString("\n\n")
+	b.WriteString(m.renderHelp())
+	return b.String()
+}
+
+// renderBulkCommand renders the prompt for the command to run in the
+// bulk targets
+func (m Model) renderBulkCommand() string {
+	var b strings.Builder
+
+	b.WriteString(compactLogo())
+	b.WriteString("\n\n")
+
+	modalStyle := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#7C3AED")).
+		Padding(1, 2).
+		Width(60)
+
+	title := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#A78BFA")).
+		Bold(true).
+		Render("▶ Run in " + targetsLabel(m.bulkTargets()))
+
+	label := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#7C3AED")).
+		Bold(true).
+		Render("$ ")
+
+	footer := lipgloss.NewStyle().
+		Foreground(mutedColor).
+		Render("\n\nRuns with sh in each repo   Enter = run   Esc = cancel")
+
+	b.WriteString(modalStyle.Render(title + "\n\n" + label + m.commandInput.View() + footer))
+	b.WriteString("\n\n")
+	b.WriteString(m.renderHelp())
+	return b.String()
+}
+
+// renderResults renders the results view of the last bulk operation
+func (m Model) renderResults() string {
+	var b strings.Builder
+
+	b.WriteString(compactLogo())
+	b.WriteString("\n\n")
+	b.WriteString(lipgloss.NewStyle().Foreground(primaryDim).Bold(true).Render(m.lastOp.title))
+	b.WriteString("\n")
+	b.WriteString(subtitleStyle.Render(m.lastOp.summary))
+	b.WriteString("\n\n")
+	b.WriteString(m.resultsView.View())
+	b.WriteString("\n\n")
+	b.WriteString(m.renderHelp())
+	return b.String()
+}
+
+// targetsLabel describes the repos a bulk action applies to
+func targetsLabel(repos []model.Repo) string {
+	if len(repos) == 1 {
+		return repos[0].Name
+	}
+	return fmt.Sprintf("%d repos", len(repos))
+}
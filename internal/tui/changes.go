@@ -0,0 +1,237 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Bharath-code/git-scope/internal/gitops"
+	"github.com/Bharath-code/git-scope/internal/model"
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// changeDoneMsg is sent when a stage, commit or stash action finished
+type changeDoneMsg struct {
+	repo model.Repo
+	done string // what the action did, for the status line
+	err  error
+}
+
+// changeCmd runs a write action on a repo in the background. Commits run
+// the repo's hooks, which may lint or test for a while, so actions get
+// the long timeout of git operations: killing git mid-hook would leave
+// the index locked.
+func changeCmd(repo model.Repo, done string, action func(ctx context.Context, dir string) error) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), gitops.DefaultTimeout)
+		defer cancel()
+		return changeDoneMsg{repo: repo, done: done, err: action(ctx, repo.Path)}
+	}
+}
+
+// startChange runs a write action on the diff view's repo unless one is
+// still running, so two git commands never race for the index lock
+func (m *Model) startChange(done string, action func(ctx context.Context, dir string) error) tea.Cmd {
+	if m.changeBusy {
+		return nil
+	}
+	m.changeBusy = true
+	return changeCmd(m.diffRepo, done, action)
+}
+
+// newCommitInput creates the commit message editor
+func newCommitInput() textarea.Model {
+	ta := textarea.New()
+	ta.Placeholder = "Commit message"
+	ta.ShowLineNumbers = false
+	ta.CharLimit = 0
+	ta.SetHeight(6)
+	return ta
+}
+
+// newStashInput creates the stash message prompt
+func newStashInput() textinput.Model {
+	si := textinput.New()
+	si.Placeholder = "optional message"
+	si.CharLimit = 200
+	si.Width = 40
+	return si
+}
+
+// handleChangeKey runs the write action bound to key in the diff view
+// and reports whether key is one
+func (m *Model) handleChangeKey(key string) (tea.Cmd, bool) {
+	f, hasFile := m.diffFile()
+
+	switch key {
+	case " ":
+		// Stage the file under the cursor, or unstage it if it has no
+		// unstaged changes left
+		if !hasFile {
+			return nil, true
+		}
+		if f.IsStaged() && !f.IsUnstaged() {
+			return m.startChange("Unstaged "+f.Path, func(ctx context.Context, dir string) error {
+				return gitops.Unstage(ctx, dir, f.Path)
+			}), true
+		}
+		return m.startChange("Staged "+f.Path, func(ctx context.Context, dir string) error {
+			return gitops.Stage(ctx, dir, f.Path)
+		}), true
+
+	case "a":
+		if len(m.diffFiles) == 0 {
+			return nil, true
+		}
+		return m.startChange("Staged "+countFiles(len(m.diffFiles)), gitops.StageAll), true
+
+	case "u":
+		if countStaged(m.diffFiles) == 0 {
+			m.statusMsg = "Nothing staged"
+			return nil, true
+		}
+		return m.startChange("Unstaged all files", gitops.UnstageAll), true
+
+	case "c":
+		if countStaged(m.diffFiles) == 0 {
+			m.statusMsg = "Nothing staged to commit · space or a to stage"
+			return nil, true
+		}
+		m.state = StateCommit
+		m.commitInput.Reset()
+		return m.commitInput.Focus(), true
+
+	case "z":
+		// Like git stash, leave untracked files alone
+		if countTracked(m.diffFiles) == 0 {
+			m.statusMsg = "No local changes to stash"
+			return nil, true
+		}
+		m.state = StateStash
+		m.stashInput.SetValue("")
+		m.stashInput.Focus()
+		return textinput.Blink, true
+
+	case "Z":
+		return m.startChange("Popped the latest stash", gitops.StashPop), true
+	}
+	return nil, false
+}
+
+// handleCommitMode handles key events while writing a commit message
+func (m Model) handleCommitMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.state = StateDiff
+		m.commitInput.Blur()
+		return m, nil
+
+	case "ctrl+s":
+		message := strings.TrimSpace(m.commitInput.Value())
+		if message == "" {
+			m.statusMsg = "Write a commit message first"
+			return m, nil
+		}
+		m.state = StateDiff
+		m.commitInput.Blur()
+		summary, _, _ := strings.Cut(message, "\n")
+		return m, m.startChange("Committed "+truncateString(summary, 50), func(ctx context.Context, dir string) error {
+			return gitops.Commit(ctx, dir, message)
+		})
+
+	case "ctrl+c":
+		return m, tea.Quit
+	}
+
+	var cmd tea.Cmd
+	m.commitInput, cmd = m.commitInput.Update(msg)
+	return m, cmd
+}
+
+// handleStashMode handles key events while entering a stash message
+func (m Model) handleStashMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.state = StateDiff
+		m.stashInput.Blur()
+		return m, nil
+
+	case "enter":
+		message := m.stashInput.Value()
+		m.state = StateDiff
+		m.stashInput.Blur()
+		return m, m.startChange("Stashed local changes", func(ctx context.Context, dir string) error {
+			return gitops.Stash(ctx, dir, message)
+		})
+
+	case "ctrl+c":
+		return m, tea.Quit
+	}
+
+	var cmd tea.Cmd
+	m.stashInput, cmd = m.stashInput.Update(msg)
+	return m, cmd
+}
+
+// countStaged counts the files with staged changes
+func countStaged(files []model.FileChange) int {
+	n := 0
+	for _, f := range files {
+		if f.IsStaged() {
+			n++
+		}
+	}
+	return n
+}
+
+// countTracked counts the files that are not untracked
+func countTracked(files []model.FileChange) int {
+	n := 0
+	for _, f := range files {
+		if !f.IsUntracked() {
+			n++
+		}
+	}
+	return n
+}
+
+// countFiles formats a number of files
+func countFiles(n int) string {
+	if n == 1 {
+		return "1 file"
+	}
+	return fmt.Sprintf("%d files", n)
+}
+
+// renderCommitBox renders the commit message editor in place of the diff
+func (m Model) renderCommitBox(width int) string {
+	title := detailSectionStyle.Render("Commit " + countFiles(countStaged(m.diffFiles)) + " staged")
+	if branch := m.diffRepo.Status.Branch; branch != "" {
+		title += panelMutedStyle.Render(" to " + branch)
+	}
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(primaryColor).
+		Padding(0, 1).
+		Width(width - 2)
+	footer := panelMutedStyle.Render("ctrl+s commit · esc cancel")
+	return box.Render(title + "\n\n" + m.commitInput.View() + "\n\n" + footer)
+}
+
+// renderStashBox renders the stash message prompt in place of the diff
+func (m Model) renderStashBox(width int) string {
+	title := detailSectionStyle.Render("Stash " + countFiles(countTracked(m.diffFiles)) + " changed")
+	if untracked := len(m.diffFiles) - countTracked(m.diffFiles); untracked > 0 {
+		title += panelMutedStyle.Render(fmt.Sprintf(" · %d untracked kept", untracked))
+	}
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(primaryColor).
+		Padding(0, 1).
+		Width(width - 2)
+	footer := panelMutedStyle.Render("enter stash · esc cancel")
+	return box.Render(title + "\n\n" + "Message: " + m.stashInput.View() + "\n\n" + footer)
+}
@@ -0,0 +1,352 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/Bharath-code/git-scope/internal/gitstatus"
+	"github.com/Bharath-code/git-scope/internal/model"
+	"github.com/Bharath-code/git-scope/internal/scan"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Diff view styles
+var (
+	diffHeaderStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#f0f6fc")).Bold(true)
+	diffMetaStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("#8b949e"))
+	diffHunkStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("#22d3ee"))
+	diffAddStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("#22c55e"))
+	diffDelStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("#ef4444"))
+	diffModeOnStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF")).Background(primaryColor).Padding(0, 1)
+	diffModeOffStyle = lipgloss.NewStyle().Foreground(mutedColor).Padding(0, 1)
+	diffCursorStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#f0f6fc")).Background(lipgloss.Color("#21262d")).Bold(true)
+)
+
+// diffFilesLoadedMsg carries the changed files of the repo in the diff view
+type diffFilesLoadedMsg struct {
+	path  string
+	files []model.FileChange
+	err   error
+}
+
+// diffLoadedMsg carries the diff of one file
+type diffLoadedMsg struct {
+	path   string
+	file   string
+	staged bool
+	text   string
+	err    error
+}
+
+// loadDiffFilesCmd lists the changed files of the repo at path
+func loadDiffFilesCmd(path string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), scan.DefaultTimeout)
+		defer cancel()
+		files, err := gitstatus.ChangedFiles(ctx, path)
+		return diffFilesLoadedMsg{path: path, files: files, err: err}
+	}
+}
+
+// loadDiffCmd loads the staged or working tree diff of a file
+func loadDiffCmd(path string, file model.FileChange, staged bool) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), scan.DefaultTimeout)
+		defer cancel()
+		text, err := gitstatus.FileDiff(ctx, path, file, staged)
+		return diffLoadedMsg{path: path, file: file.Path, staged: staged, text: text, err: err}
+	}
+}
+
+// openDiff switches to the diff view of the highlighted repo
+func (m *Model) openDiff() tea.Cmd {
+	repo := m.GetSelectedRepo()
+	if repo == nil {
+		return nil
+	}
+	m.state = StateDiff
+	m.diffRepo = *repo
+	m.diffFiles = nil
+	m.diffCursor = 0
+	m.diffLoaded = false
+	m.diffErr = nil
+	m.diffText = ""
+	m.diffShown = ""
+	m.statusMsg = ""
+	m.resizeDiff()
+	return loadDiffFilesCmd(repo.Path)
+}
+
+// reloadDiff lists the changed files of the diff view's repo again,
+// keeping the cursor on the same file if it is still changed
+func (m *Model) reloadDiff() tea.Cmd {
+	return loadDiffFilesCmd(m.diffRepo.Path)
+}
+
+// diffFile returns the file under the cursor of the diff view
+func (m Model) diffFile() (model.FileChange, bool) {
+	if m.diffCursor < 0 || m.diffCursor >= len(m.diffFiles) {
+		return model.FileChange{}, false
+	}
+	return m.diffFiles[m.diffCursor], true
+}
+
+// setDiffFiles replaces the file list of the diff view and loads the diff
+// of the file under the cursor
+func (m *Model) setDiffFiles(files []model.FileChange) tea.Cmd {
+	current, _ := m.diffFile()
+	m.diffFiles = files
+	m.diffCursor = 0
+	for i, f := range files {
+		if f.Path == current.Path {
+			m.diffCursor = i
+			break
+		}
+	}
+	return m.selectDiffFile(m.diffCursor)
+}
+
+// selectDiffFile moves the cursor to file i and loads its diff. A file
+// changed on one side only shows that side; for a file changed on both,
+// the staged or working tree view chosen last is kept.
+func (m *Model) selectDiffFile(i int) tea.Cmd {
+	m.diffCursor = i
+	f, ok := m.diffFile()
+	if !ok {
+		m.diffText = ""
+		m.diffErr = nil
+		m.diffLoaded = true
+		m.setDiffContent()
+		return nil
+	}
+	m.diffStaged = f.IsStaged() && (!f.IsUnstaged() || m.diffStaged)
+	return m.loadDiff()
+}
+
+// loadDiff loads the diff of the file under the cursor in the current
+// staged or working tree mode
+func (m *Model) loadDiff() tea.Cmd {
+	f, ok := m.diffFile()
+	if !ok {
+		return nil
+	}
+	// A reload of the shown diff keeps it on screen until the new one is in
+	if diffKey(f.Path, m.diffStaged) != m.diffShown {
+		m.diffLoaded = false
+		m.setDiffContent()
+	}
+	return loadDiffCmd(m.diffRepo.Path, f, m.diffStaged)
+}
+
+// handleDiffMode handles key events in the diff view
+func (m Model) handleDiffMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+
+	case "esc", "q", "D":
+		m.state = StateReady
+		return m, nil
+
+	case "up", "shift+tab":
+		if m.diffCursor > 0 {
+			return m, m.selectDiffFile(m.diffCursor - 1)
+		}
+		return m, nil
+
+	case "down", "tab":
+		if m.diffCursor < len(m.diffFiles)-1 {
+			return m, m.selectDiffFile(m.diffCursor + 1)
+		}
+		return m, nil
+
+	case "s":
+		// Switch between the staged and working tree changes
+		if _, ok := m.diffFile(); ok {
+			m.diffStaged = !m.diffStaged
+			return m, m.loadDiff()
+		}
+		return m, nil
+
+	case "r":
+		return m, m.reloadDiff()
+
+	case "e":
+		// Open the file under the cursor in the editor
+		if f, ok := m.diffFile(); ok {
+			path := filepath.Join(m.diffRepo.Path, f.Path)
+			return m, func() tea.Msg {
+				return openEditorMsg{paths: []string{path}}
+			}
+		}
+		return m, nil
+	}
+
+	if cmd, ok := m.handleChangeKey(msg.String()); ok {
+		return m, cmd
+	}
+
+	var cmd tea.Cmd
+	m.diffView, cmd = m.diffView.Update(msg)
+	return m, cmd
+}
+
+// diffListWidth is the width of the file list beside the diff
+func (m Model) diffListWidth() int {
+	w := m.width / 3
+	if w > 40 {
+		w = 40
+	}
+	if w < 16 {
+		w = 16
+	}
+	return w
+}
+
+// resizeDiff fits the diff viewport to the window
+func (m *Model) resizeDiff() {
+	m.diffView.Width = m.width - m.diffListWidth() - 7
+	m.diffView.Height = m.height - 11
+	if m.diffView.Width < 10 {
+		m.diffView.Width = 10
+	}
+	if m.diffView.Height < 1 {
+		m.diffView.Height = 1
+	}
+	m.commitInput.SetWidth(m.diffView.Width - 6)
+	m.setDiffContent()
+}
+
+// setDiffContent renders the loaded diff into the viewport
+func (m *Model) setDiffContent() {
+	switch {
+	case m.diffErr != nil:
+		m.diffView.SetContent(panelMutedStyle.Render("Could not load diff: " + m.diffErr.Error()))
+	case !m.diffLoaded:
+		m.diffView.SetContent(panelMutedStyle.Render("Loading diff..."))
+	case len(m.diffFiles) == 0:
+		m.diffView.SetContent(panelMutedStyle.Render("Working tree clean"))
+	case m.diffText == "":
+		mode := "working tree"
+		if m.diffStaged {
+			mode = "staged"
+		}
+		m.diffView.SetContent(panelMutedStyle.Render("No " + mode + " changes for this file · s to switch"))
+	default:
+		m.diffView.SetContent(colorizeDiff(m.diffText, m.diffView.Width))
+	}
+}
+
+// colorizeDiff colors the lines of a unified diff by their role, cutting
+// them to width so the viewport never wraps
+func colorizeDiff(diff string, width int) string {
+	lines := strings.Split(strings.TrimRight(diff, "\n"), "\n")
+	out := make([]string, len(lines))
+	for i, line := range lines {
+		line = strings.ReplaceAll(line, "\t", "    ")
+		if width > 1 {
+			line = truncateString(line, width)
+		}
+		out[i] = diffLineStyle(line).Render(line)
+	}
+	return strings.Join(out, "\n")
+}
+
+// diffLineStyle returns the style of one unified diff line
+func diffLineStyle(line string) lipgloss.Style {
+	switch {
+	case strings.HasPrefix(line, "diff "):
+		return diffHeaderStyle
+	case strings.HasPrefix(line, "+++ "), strings.HasPrefix(line, "--- "):
+		return diffHeaderStyle
+	case strings.HasPrefix(line, "@@"):
+		return diffHunkStyle
+	case strings.HasPrefix(line, "+"):
+		return diffAddStyle
+	case strings.HasPrefix(line, "-"):
+		return diffDelStyle
+	case strings.HasPrefix(line, " "), line == "":
+		return lipgloss.NewStyle()
+	}
+	// index, mode, rename and binary lines, and "\ No newline at end of file"
+	return diffMetaStyle
+}
+
+// renderDiff renders the diff view: the changed files on the left and the
+// diff of the file under the cursor on the right
+func (m Model) renderDiff() string {
+	var b strings.Builder
+
+	b.WriteString(compactLogo())
+	b.WriteString("\n\n")
+
+	title := "± " + m.diffRepo.Name
+	if f, ok := m.diffFile(); ok {
+		title += fmt.Sprintf(" · %s (%d/%d)", fileChangePath(f), m.diffCursor+1, len(m.diffFiles))
+	}
+	b.WriteString(lipgloss.NewStyle().Foreground(primaryDim).Bold(true).Render(truncateString(title, m.width-30)))
+	b.WriteString("  ")
+	worktree, staged := diffModeOnStyle, diffModeOffStyle
+	if m.diffStaged {
+		worktree, staged = staged, worktree
+	}
+	b.WriteString(worktree.Render("working tree") + staged.Render("staged"))
+	b.WriteString("\n\n")
+
+	list := m.renderDiffFiles(m.diffListWidth(), m.diffView.Height)
+	sep := lipgloss.NewStyle().Foreground(borderColor).Render(strings.Repeat("│\n", m.diffView.Height-1) + "│")
+	pane := m.diffView.View()
+	switch m.state {
+	case StateCommit:
+		pane = m.renderCommitBox(m.diffView.Width)
+	case StateStash:
+		pane = m.renderStashBox(m.diffView.Width)
+	}
+	b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, list, " ", sep, " ", pane))
+	b.WriteString("\n")
+	b.WriteString(subtitleStyle.Render(truncateString(m.statusMsg, m.width-6)))
+	b.WriteString("\n")
+	b.WriteString(m.renderHelp())
+	return b.String()
+}
+
+// renderDiffFiles renders the file list of the diff view, scrolled so the
+// cursor stays visible
+func (m Model) renderDiffFiles(width, height int) string {
+	style := lipgloss.NewStyle().Width(width).MaxWidth(width).Height(height).MaxHeight(height)
+	if !m.diffLoaded && m.diffFiles == nil {
+		return style.Render(panelMutedStyle.Render("Loading..."))
+	}
+	if len(m.diffFiles) == 0 {
+		return style.Render(panelMutedStyle.Render("No changes"))
+	}
+
+	start := 0
+	if m.diffCursor >= height {
+		start = m.diffCursor - height + 1
+	}
+	var lines []string
+	for i := start; i < len(m.diffFiles) && i < start+height; i++ {
+		f := m.diffFiles[i]
+		name := truncateString(fileChangePath(f), width-4)
+		if i == m.diffCursor {
+			name = diffCursorStyle.Render(name)
+		}
+		lines = append(lines, fileChangeCode(f)+" "+name)
+	}
+	return style.Render(strings.Join(lines, "\n"))
+}
+
+// diffKey identifies the diff shown for a file in one mode
+func diffKey(file string, staged bool) string {
+	return fmt.Sprintf("%s\x00%t", file, staged)
+}
+
+// inDiffView reports whether the diff view is shown, possibly with the
+// commit or stash prompt over it
+func (m Model) inDiffView() bool {
+	return m.state == StateDiff || m.state == StateCommit || m.state == StateStash
+}
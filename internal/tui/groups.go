@@ -0,0 +1,226 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Bharath-code/git-scope/internal/config"
+	"github.com/Bharath-code/git-scope/internal/filter"
+	"github.com/Bharath-code/git-scope/internal/model"
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/mattn/go-runewidth"
+)
+
+// parseTags converts the tags of the config, failing on the first one
+// that is invalid
+func parseTags(cfg *config.Config) ([]filter.Tag, error) {
+	tags := make([]filter.Tag, len(cfg.Tags))
+	for i, t := range cfg.Tags {
+		tags[i] = filter.Tag{Name: t.Name, Paths: t.Paths}
+		if err := tags[i].Validate(); err != nil {
+			return nil, fmt.Errorf("tags: %w", err)
+		}
+	}
+	return tags, nil
+}
+
+// parseGroup validates a group key of the config
+func parseGroup(s string, cfg *config.Config) (filter.GroupKey, error) {
+	key, err := filter.ParseGroupKey(s)
+	if err != nil {
+		return key, err
+	}
+	if key == filter.GroupTag && len(cfg.Tags) == 0 {
+		return key, fmt.Errorf("group tag: no tags are configured")
+	}
+	return key, nil
+}
+
+// groupName returns the display name of a group key
+func groupName(key filter.GroupKey) string {
+	switch key {
+	case filter.GroupRoot:
+		return "Root"
+	case filter.GroupParent:
+		return "Folder"
+	case filter.GroupRemote:
+		return "Remote"
+	case filter.GroupTag:
+		return "Tag"
+	}
+	return "None"
+}
+
+// grouped reports whether repos are shown in groups
+func (m Model) grouped() bool {
+	return m.groupBy != filter.GroupNone
+}
+
+// grouping returns how repos are currently grouped
+func (m Model) grouping() filter.Grouping {
+	return filter.Grouping{Key: m.groupBy, Roots: m.roots(), Tags: m.tags}
+}
+
+// groupID identifies a group across regroupings, since a directory can
+// name both a root and a parent group
+func (m Model) groupID(name string) string {
+	return string(m.groupBy) + ":" + name
+}
+
+// cycleGroup switches to the next way of grouping repos, skipping tags
+// when none are configured
+func (m *Model) cycleGroup() {
+	keys := append([]filter.GroupKey{filter.GroupNone}, filter.GroupKeys...)
+	next := 0
+	for i, k := range keys {
+		if k == m.groupBy {
+			next = (i + 1) % len(keys)
+		}
+	}
+	if keys[next] == filter.GroupTag && len(m.tags) == 0 {
+		next = (next + 1) % len(keys)
+	}
+	m.groupBy = keys[next]
+	m.resetPage()
+	m.updateTable()
+	m.table.SetCursor(0)
+	m.scrollTable()
+}
+
+// groupRows returns the rows of the grouped table: a header for every
+// group, followed by its repos unless the group is folded
+func (m Model) groupRows() []repoRow {
+	var rows []repoRow
+	for _, g := range m.groups {
+		folded := m.collapsed[m.groupID(g.Name)]
+		rows = append(rows, m.groupHeaderRow(g, folded))
+		if !folded {
+			rows = append(rows, m.reposToRows(g.Repos)...)
+		}
+	}
+	return rows
+}
+
+// groupHeaderRow returns the header row of a group with its counts of
+// repos, dirty repos and repos ahead of their upstream
+func (m Model) groupHeaderRow(g filter.Group, folded bool) repoRow {
+	dirty, ahead := 0, 0
+	for _, r := range g.Repos {
+		if r.Status.IsDirty {
+			dirty++
+		}
+		if r.Status.Ahead > 0 {
+			ahead++
+		}
+	}
+	parts := []string{fmt.Sprintf("%d repos", len(g.Repos))}
+	if len(g.Repos) == 1 {
+		parts[0] = "1 repo"
+	}
+	if dirty > 0 {
+		parts = append(parts, fmt.Sprintf("● %d dirty", dirty))
+	}
+	if ahead > 0 {
+		parts = append(parts, fmt.Sprintf("↑ %d ahead", ahead))
+	}
+
+	label := g.Name
+	if m.groupBy == filter.GroupRoot || m.groupBy == filter.GroupParent {
+		label = displayRepoPath(label)
+	}
+	return repoRow{
+		cells:   make(table.Row, len(m.columns)),
+		group:   g.Name,
+		label:   label,
+		summary: strings.Join(parts, " · "),
+		folded:  folded,
+	}
+}
+
+// renderGroupHeader renders the header row of a group across width
+// display columns
+func renderGroupHeader(row repoRow, width int, selected bool) string {
+	arrow := "▾ "
+	if row.folded {
+		arrow = "▸ "
+	}
+	label, summary := arrow+row.label, "  "+row.summary
+	room := width - 1
+	if runewidth.StringWidth(label) >= room {
+		label, summary = runewidth.Truncate(label, room, "…"), ""
+	} else {
+		summary = runewidth.Truncate(summary, room-runewidth.StringWidth(label), "…")
+	}
+	pad := width - 1 - runewidth.StringWidth(label) - runewidth.StringWidth(summary)
+	if pad < 0 {
+		pad = 0
+	}
+	if selected {
+		return repoTableStyles.Selected.Render(" " + label + summary + strings.Repeat(" ", pad))
+	}
+	return " " + groupHeaderStyle.Render(label) + hintStyle.Render(summary) + strings.Repeat(" ", pad)
+}
+
+// selectedGroup returns the group whose header is under the cursor
+func (m Model) selectedGroup() (filter.Group, bool) {
+	cursor := m.table.Cursor()
+	if !m.grouped() || cursor < 0 || cursor >= len(m.rows) || m.rows[cursor].group == "" {
+		return filter.Group{}, false
+	}
+	for _, g := range m.groups {
+		if g.Name == m.rows[cursor].group {
+			return g, true
+		}
+	}
+	return filter.Group{}, false
+}
+
+// rowRepo returns the repo of row i of the grouped table, or nil for a
+// group header
+func (m Model) rowRepo(i int) *model.Repo {
+	if i < 0 || i >= len(m.rows) || m.rows[i].path == "" {
+		return nil
+	}
+	for j := range m.sortedRepos {
+		if m.sortedRepos[j].Path == m.rows[i].path {
+			return &m.sortedRepos[j]
+		}
+	}
+	return nil
+}
+
+// moveToRow puts the cursor on the first row of the repo at path, or
+// else on the header of group
+func (m *Model) moveToRow(path, group string) {
+	for i, row := range m.rows {
+		if (path != "" && row.path == path) || (group != "" && row.group == group) {
+			m.table.SetCursor(i)
+			m.scrollTable()
+			return
+		}
+	}
+}
+
+// toggleGroup folds or unfolds a group, leaving the cursor on its header
+func (m *Model) toggleGroup(g filter.Group) {
+	id := m.groupID(g.Name)
+	if m.collapsed[id] {
+		delete(m.collapsed, id)
+	} else {
+		m.collapsed[id] = true
+	}
+	m.setTableRows()
+	m.moveToRow("", g.Name)
+}
+
+// jumpGroup moves the cursor to the header of the next group, or of the
+// previous one when dir is negative
+func (m *Model) jumpGroup(dir int) {
+	for i := m.table.Cursor() + dir; i >= 0 && i < len(m.rows); i += dir {
+		if m.rows[i].group != "" {
+			m.table.SetCursor(i)
+			m.scrollTable()
+			return
+		}
+	}
+}
@@ -1,18 +1,26 @@
 package tui
 
 import (
+	"context"
 	"fmt"
-	"sort"
 	"strings"
+	"time"
 
 	"github.com/Bharath-code/git-scope/internal/config"
+	"github.com/Bharath-code/git-scope/internal/filter"
+	"github.com/Bharath-code/git-scope/internal/gitops"
 	"github.com/Bharath-code/git-scope/internal/model"
+	"github.com/Bharath-code/git-scope/internal/scan"
 	"github.com/Bharath-code/git-scope/internal/stats"
+	"github.com/Bharath-code/git-scope/internal/watch"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
 )
 
 // State represents the current UI state
@@ -24,6 +32,14 @@ const (
 	StateError
 	StateSearching
 	StateWorkspaceSwitch
+	StateBulkMenu
+	StateBulkCommand
+	StateResults
+	StateDiff
+	StateCommit
+	StateStash
+	StateConfirmPush
+	StateViewPicker
 )
 
 // SortMode represents different sorting options
@@ -43,12 +59,55 @@ const (
 	FilterAll FilterMode = iota
 	FilterDirty
 	FilterClean
+	FilterStashed
+	FilterInProgress
+	FilterNoUpstream
+	FilterUnpushed
+
+	// filterModeCount is the number of filter modes; keep it last
+	filterModeCount
 )
 
+// key returns the shared sort key for the sort mode
+func (s SortMode) key() filter.SortKey {
+	switch s {
+	case SortByName:
+		return filter.SortName
+	case SortByBranch:
+		return filter.SortBranch
+	case SortByLastCommit:
+		return filter.SortRecent
+	}
+	return filter.SortDirty
+}
+
+// conditions returns the repo conditions the filter mode shows, or nil
+// for all repos
+func (f FilterMode) conditions() []filter.Condition {
+	switch f {
+	case FilterDirty:
+		return []filter.Condition{filter.Dirty}
+	case FilterClean:
+		return []filter.Condition{filter.Clean}
+	case FilterStashed:
+		return []filter.Condition{filter.Stashed}
+	case FilterInProgress:
+		return []filter.Condition{filter.InProgress}
+	case FilterNoUpstream:
+		return []filter.Condition{filter.NoUpstream}
+	case FilterUnpushed:
+		return []filter.Condition{filter.Unpushed}
+	}
+	return nil
+}
+
 // Model is the Bubbletea model for the TUI
 type Model struct {
 	cfg           *config.Config
 	table         table.Model
+	rows          []repoRow    // rows of the table, see setTableRows
+	columns       []repoColumn // columns of the table
+	tableTop      int          // first row of the table in view
 	textInput     textinput.Model
 	spinner       spinner.Model
 	repos         []model.Repo
@@ -62,11 +121,16 @@ type Model struct {
 	sortMode      SortMode
 	filterMode    FilterMode
 	searchQuery   string
+	query         *filter.Query      // last search query that parsed
+	queryErr      *filter.QueryError // why searchQuery does not parse
 	// Panel state
 	activePanel  PanelType
 	grassData    *stats.ContributionData
 	diskData     *stats.DiskUsageData
 	timelineData *stats.TimelineData
+	detailPath   string // repo shown in the detail panel
+	detailData   *model.RepoDetails
+	detailErr    error
 	// Workspace switch state
 	workspaceInput  textinput.Model
 	workspaceError  string
@@ -77,54 +141,81 @@ type Model struct {
 	// Pagination state
 	currentPage int
 	pageSize    int
+	// In-flight scan, cancelled when a new scan starts or on quit
+	scanCtx    context.Context
+	cancelScan context.CancelFunc
+	// Streaming scan progress
+	scanID        int             // identifies the current scan; stale events are dropped
+	scanning      bool            // a streaming scan is delivering results
+	scanFound     int             // repos discovered by the current scan
+	scanQueried   int             // repos whose status has been collected
+	scanSeen      map[string]bool // repo paths reported by the current scan
+	pending       map[string]bool // repo paths found but not yet queried
+	scanWorkspace string          // workspace being switched to, if any
+	revalidating  bool            // the current scan refreshes rows already shown
+	cachedAt      time.Time       // age of displayed cached data until revalidated
+	// Watch mode: live updates for repos changed on disk
+	watchEnabled bool
+	watcher      *watch.Watcher
+	// Marked repos and the bulk-action menu
+	selected     map[string]bool // paths of marked repos
+	bulkCursor   int
+	commandInput textinput.Model
+	// Bulk operation (fetch, pull, run) running across repos
+	opName        string // verb of the running operation, empty when idle
+	opID          int    // identifies the current operation; stale results are dropped
+	cancelOp      context.CancelFunc
+	opTotal       int
+	opResults     []gitops.Result
+	opShowResults bool           // open the results view when done
+	opCommand     string         // command line of a run operation
+	opOutput      *commandOutput // output of a run operation
+	// Results of the last finished operation
+	lastOp      *opReport
+	resultsView viewport.Model
+	// Diff view of one repo's changed files
+	diffRepo   model.Repo
+	diffFiles  []model.FileChange
+	diffCursor int
+	diffStaged bool   // show staged rather than working tree changes
+	diffLoaded bool   // diffText holds the diff of the file under the cursor
+	diffShown  string // diffKey of diffText, to keep the scroll on reload
+	diffText   string
+	diffErr    error
+	diffView   viewport.Model
+	// Staging, commit and stash actions in the diff view
+	commitInput textarea.Model
+	stashInput  textinput.Model
+	changeBusy  bool // a write action is running
+	// Repos a push waits for confirmation to run on
+	pushTargets []model.Repo
+	// Saved views of the config
+	defaultView viewPreset
+	views       []viewPreset
+	configErr   error  // why the views, columns or tags of the config are invalid
+	activeView  string // name of the view last applied, "" for the default
+	viewCursor  int
+	// Grouped display, which replaces paging
+	groupBy   filter.GroupKey
+	groups    []filter.Group  // groups of sortedRepos
+	tags      []filter.Tag    // tags of the config
+	collapsed map[string]bool // folded groups, see groupID
 }
 
 // NewModel creates a new TUI model
 func NewModel(cfg *config.Config) Model {
-	columns := []table.Column{
-		{Title: "Status", Width: 8},
-		{Title: "Repository", Width: 18},
-		{Title: "Branch", Width: 14},
-		{Title: "Staged", Width: 6},
-		{Title: "Modified", Width: 8},
-		{Title: "Untracked", Width: 9},
-		{Title: "Last Commit", Width: 14},
-	}
-
 	t := table.New(
-		table.WithColumns(columns),
 		table.WithRows([]table.Row{}),
 		table.WithFocused(true),
 		table.WithHeight(12),
 	)
-
-	// Apply modern table styles with strong highlighting
-	s := table.DefaultStyles()
-	s.Header = s.Header.
-		BorderStyle(lipgloss.NormalBorder()).
-		BorderForeground(lipgloss.Color("#7C3AED")).
-		BorderBottom(true).
-		Bold(true).
-		Foreground(lipgloss.Color("#FFFFFF")).
-		Background(lipgloss.Color("#7C3AED")).
-		Padding(0, 1)
-
-	// Strong row highlighting
-	s.Selected = s.Selected.
-		Foreground(lipgloss.Color("#000000")).
-		Background(lipgloss.Color("#A78BFA")).
-		Bold(true)
-
-	s.Cell = s.Cell.
-		Padding(0, 1)
-
-	t.SetStyles(s)
+	t.SetStyles(repoTableStyles)
 
 	// Create text input for search
 	ti := textinput.New()
-	ti.Placeholder = "Search repos..."
-	ti.CharLimit = 50
-	ti.Width = 30
+	ti.Placeholder = "Search repos, or e.g. dirty ahead>0 branch:feat/*"
+	ti.CharLimit = 200
+	ti.Width = 60
 
 	// Create text input for workspace switch
 	wi := textinput.New()
@@ -132,28 +223,123 @@ func NewModel(cfg *config.Config) Model {
 	wi.CharLimit = 200
 	wi.Width = 40
 
+	// Create text input for bulk commands
+	ci := textinput.New()
+	ci.Placeholder = "git status -s"
+	ci.CharLimit = 500
+	ci.Width = 50
+
 	// Create spinner with Braille pattern
 	sp := spinner.New()
 	sp.Spinner = spinner.Dot
 	sp.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("#7C3AED"))
 
-	return Model{
+	scanCtx, cancelScan := context.WithCancel(context.Background())
+
+	m := Model{
 		cfg:            cfg,
 		table:          t,
 		textInput:      ti,
 		workspaceInput: wi,
+		commandInput:   ci,
+		resultsView:    viewport.New(0, 0),
+		diffView:       viewport.New(0, 0),
+		commitInput:    newCommitInput(),
+		stashInput:     newStashInput(),
+		selected:       make(map[string]bool),
+		collapsed:      make(map[string]bool),
 		spinner:        sp,
 		state:          StateLoading,
 		sortMode:       SortByDirty,
 		filterMode:     FilterAll,
 		currentPage:    0,
 		pageSize:       15,
+		scanCtx:        scanCtx,
+		cancelScan:     cancelScan,
+		scanSeen:       make(map[string]bool),
+		pending:        make(map[string]bool),
+		watchEnabled:   cfg.Watch,
+	}
+	m.defaultView, m.views, m.configErr = parseViews(cfg)
+	if tags, err := parseTags(cfg); err != nil {
+		m.configErr = err
+	} else {
+		m.tags = tags
 	}
+	m.groupBy = m.defaultView.group
+	m.setColumns(m.defaultView.columns)
+	return m
 }
 
 // Init initializes the model
 func (m Model) Init() tea.Cmd {
-	return tea.Batch(m.spinner.Tick, scanReposCmd(m.cfg))
+	return tea.Batch(m.spinner.Tick, loadReposCmd(m.scanCtx, m.scanID, m.cfg))
+}
+
+// newScanContext aborts the scan in flight, if any, resets the streaming
+// progress and returns the context for the next scan
+func (m *Model) newScanContext() context.Context {
+	m.cancelScan()
+	m.scanCtx, m.cancelScan = context.WithCancel(context.Background())
+	m.scanID++
+	m.scanning = false
+	m.scanFound = 0
+	m.scanQueried = 0
+	m.scanSeen = make(map[string]bool)
+	m.pending = make(map[string]bool)
+	m.scanWorkspace = ""
+	m.revalidating = false
+	return m.scanCtx
+}
+
+// applyScanEvents merges a batch of streaming scan events into the repo
+// list. Found repos are added as pending; status events replace them.
+func (m *Model) applyScanEvents(events []scan.Event) {
+	m.scanning = true
+
+	index := make(map[string]int, len(m.repos))
+	for i, r := range m.repos {
+		index[r.Path] = i
+	}
+
+	for _, ev := range events {
+		path := ev.Repo.Path
+		i, known := index[path]
+
+		switch ev.Type {
+		case scan.EventFound:
+			m.scanFound++
+			m.scanSeen[path] = true
+			if !known {
+				m.pending[path] = true
+				index[path] = len(m.repos)
+				m.repos = append(m.repos, ev.Repo)
+			}
+		case scan.EventStatus:
+			m.scanQueried++
+			delete(m.pending, path)
+			if known {
+				m.repos[i] = ev.Repo
+			} else {
+				index[path] = len(m.repos)
+				m.repos = append(m.repos, ev.Repo)
+			}
+		}
+	}
+}
+
+// finishScan drops repos that the completed scan no longer reports
+func (m *Model) finishScan() {
+	m.scanning = false
+	m.cachedAt = time.Time{}
+
+	repos := make([]model.Repo, 0, len(m.repos))
+	for _, r := range m.repos {
+		if m.scanSeen[r.Path] {
+			repos = append(repos, r)
+		}
+	}
+	m.repos = repos
 }
 
 // GetSelectedRepo returns the currently selected repo
@@ -164,6 +350,9 @@ func (m Model) GetSelectedRepo() *model.Repo {
 
 	// Get the cursor position within the current page
 	cursor := m.table.Cursor()
+	if m.grouped() {
+		return m.rowRepo(cursor)
+	}
 	// Calculate the actual index in sortedRepos
 	actualIndex := m.currentPage*m.pageSize + cursor
 
@@ -175,63 +364,139 @@ func (m Model) GetSelectedRepo() *model.Repo {
 
 // applyFilter filters repos based on current filter mode and search query
 func (m *Model) applyFilter() {
-	m.filteredRepos = make([]model.Repo, 0, len(m.repos))
+	if m.query != nil {
+		m.query.Roots = m.roots()
+	}
+	m.filteredRepos = filter.Apply(m.repos, filter.Criteria{
+		Conditions: m.filterMode.conditions(),
+		Query:      m.query,
+	})
+}
+
+// roots returns the directories repos are currently scanned from
+func (m Model) roots() []string {
+	if m.activeWorkspace != "" {
+		return []string{m.activeWorkspace}
+	}
+	return m.cfg.Roots
+}
+
+// setSearchQuery sets the search text and parses it. A query that does
+// not parse leaves the last valid one in effect and records the error.
+func (m *Model) setSearchQuery(s string) {
+	m.searchQuery = s
+	q, err := filter.ParseQuery(s)
+	if err != nil {
+		m.queryErr, _ = err.(*filter.QueryError)
+		return
+	}
+	m.queryErr = nil
+	m.query = q
+	if strings.TrimSpace(s) == "" {
+		m.query = nil
+	}
+}
+
+// sortRepos sorts the filtered repos based on current sort mode, and
+// groups them when grouping is on
+func (m *Model) sortRepos() {
+	m.sortedRepos = filter.Sort(m.filteredRepos, m.sortMode.key())
+	if m.ranking() {
+		// Best matches first; worktrees are not kept under their parent
+		// since that would bury them
+		m.sortedRepos = m.query.Rank(m.sortedRepos)
+	} else {
+		// Keep linked worktrees under their parent repo
+		m.sortedRepos = scan.GroupWorktrees(m.sortedRepos)
+	}
 
+	m.groups = nil
+	if m.grouped() {
+		m.groups = m.grouping().Apply(m.sortedRepos)
+	}
+}
+
+// ranking reports whether repos are sorted by how well they match the
+// search text rather than by the sort mode
+func (m Model) ranking() bool {
+	return m.query != nil && len(m.query.Text()) > 0
+}
+
+// updateRepo replaces the repo with the same path, reporting whether it
+// was found
+func (m *Model) updateRepo(repo model.Repo) bool {
+	for i, r := range m.repos {
+		if r.Path == repo.Path {
+			m.repos[i] = repo
+			return true
+		}
+	}
+	return false
+}
+
+// findRepo returns the repo at path, if known
+func (m Model) findRepo(path string) (model.Repo, bool) {
 	for _, r := range m.repos {
-		// Apply filter mode
-		switch m.filterMode {
-		case FilterDirty:
-			if !r.Status.IsDirty {
-				continue
-			}
-		case FilterClean:
-			if r.Status.IsDirty {
-				continue
-			}
+		if r.Path == path {
+			return r, true
 		}
+	}
+	return model.Repo{}, false
+}
 
-		// Apply search query
-		if m.searchQuery != "" {
-			query := strings.ToLower(m.searchQuery)
-			name := strings.ToLower(r.Name)
-			branch := strings.ToLower(r.Status.Branch)
+// startOp starts a bulk git operation on repos, reporting progress to
+// the model as each repo finishes
+func (m *Model) startOp(name string, repos []model.Repo, op gitops.Op) tea.Cmd {
+	ctx, cancel := context.WithCancel(context.Background())
+	m.opName = name
+	m.opID++
+	m.cancelOp = cancel
+	m.opTotal = len(repos)
+	m.opResults = nil
+	m.opShowResults = false
+	m.opCommand = ""
+	m.opOutput = nil
+	return runOpCmd(ctx, m.opID, repos, op)
+}
 
-			// Only search Name and Branch to avoid matching parent paths
-			if !strings.Contains(name, query) &&
-				!strings.Contains(branch, query) {
-				continue
-			}
-		}
+// finishOp clears the state of the finished bulk operation, keeping its
+// results for the results view, and returns a summary of its outcome
+func (m *Model) finishOp() string {
+	m.cancelOp()
+	summary := opSummary(m.opName, m.opTotal, m.opResults)
+	m.lastOp = newReport(m.opName, m.opCommand, summary, m.opResults, m.opOutput)
+	m.opName = ""
+	m.cancelOp = nil
+	m.opOutput = nil
+	return summary
+}
 
-		m.filteredRepos = append(m.filteredRepos, r)
+// syncDetail loads the details of the highlighted repo when the detail
+// panel is open and the cursor moved to another repo
+func (m *Model) syncDetail() tea.Cmd {
+	if m.activePanel != PanelDetail {
+		return nil
 	}
+	repo := m.GetSelectedRepo()
+	if repo == nil || repo.Path == m.detailPath {
+		return nil
+	}
+	return m.reloadDetail(repo.Path)
 }
 
-// sortRepos sorts the filtered repos based on current sort mode
-func (m *Model) sortRepos() {
-	m.sortedRepos = make([]model.Repo, len(m.filteredRepos))
-	copy(m.sortedRepos, m.filteredRepos)
+// reloadDetail starts loading the details of the repo at path
+func (m *Model) reloadDetail(path string) tea.Cmd {
+	m.detailPath = path
+	m.detailData = nil
+	m.detailErr = nil
+	return loadDetailCmd(path)
+}
 
-	switch m.sortMode {
-	case SortByDirty:
-		sort.Slice(m.sortedRepos, func(i, j int) bool {
-			if m.sortedRepos[i].Status.IsDirty != m.sortedRepos[j].Status.IsDirty {
-				return m.sortedRepos[i].Status.IsDirty
-			}
-			return m.sortedRepos[i].Name < m.sortedRepos[j].Name
-		})
-	case SortByName:
-		sort.Slice(m.sortedRepos, func(i, j int) bool {
-			return m.sortedRepos[i].Name < m.sortedRepos[j].Name
-		})
-	case SortByBranch:
-		sort.Slice(m.sortedRepos, func(i, j int) bool {
-			return m.sortedRepos[i].Status.Branch < m.sortedRepos[j].Status.Branch
-		})
-	case SortByLastCommit:
-		sort.Slice(m.sortedRepos, func(i, j int) bool {
-			return m.sortedRepos[i].Status.LastCommit.After(m.sortedRepos[j].Status.LastCommit)
-		})
+// stopWatching closes the active watcher, if any
+func (m *Model) stopWatching() {
+	if m.watcher != nil {
+		m.watcher.Close()
+		m.watcher = nil
 	}
 }
 
@@ -239,12 +504,46 @@ func (m *Model) sortRepos() {
 func (m *Model) updateTable() {
 	m.applyFilter()
 	m.sortRepos()
-	m.table.SetRows(reposToRows(m.getCurrentPageRepos()))
+	m.setTableRows()
+}
+
+// refreshTable is like updateTable but keeps the cursor on the repo that
+// was selected, moving to its new page if the sort order changed
+func (m *Model) refreshTable() {
+	selected := ""
+	if repo := m.GetSelectedRepo(); repo != nil {
+		selected = repo.Path
+	}
+
+	group := ""
+	if g, ok := m.selectedGroup(); ok {
+		group = g.Name
+	}
+
+	m.updateTable()
+	if m.grouped() {
+		m.moveToRow(selected, group)
+		return
+	}
+	if selected == "" {
+		return
+	}
+
+	for i, r := range m.sortedRepos {
+		if r.Path == selected {
+			m.currentPage = i / m.pageSize
+			m.setTableRows()
+			m.table.SetCursor(i % m.pageSize)
+			m.scrollTable()
+			return
+		}
+	}
 }
 
-// getTotalPages returns the total number of pages
+// getTotalPages returns the total number of pages. Grouped repos are
+// all on one page.
 func (m Model) getTotalPages() int {
-	if len(m.sortedRepos) == 0 {
+	if len(m.sortedRepos) == 0 || m.grouped() {
 		return 1
 	}
 	return (len(m.sortedRepos) + m.pageSize - 1) / m.pageSize
@@ -287,7 +586,15 @@ func (m *Model) resetPage() {
 
 // GetSortModeName returns the display name of current sort mode
 func (m Model) GetSortModeName() string {
-	switch m.sortMode {
+	if m.ranking() {
+		return "Best Match"
+	}
+	return m.sortMode.name()
+}
+
+// name returns the display name of the sort mode
+func (s SortMode) name() string {
+	switch s {
 	case SortByDirty:
 		return "Dirty First"
 	case SortByName:
@@ -302,51 +609,227 @@ func (m Model) GetSortModeName() string {
 
 // GetFilterModeName returns the display name of current filter mode
 func (m Model) GetFilterModeName() string {
-	switch m.filterMode {
+	return m.filterMode.name()
+}
+
+// name returns the display name of the filter mode
+func (f FilterMode) name() string {
+	switch f {
 	case FilterAll:
 		return "All"
 	case FilterDirty:
 		return "Dirty Only"
 	case FilterClean:
 		return "Clean Only"
+	case FilterStashed:
+		return "Stashed"
+	case FilterInProgress:
+		return "In Progress"
+	case FilterNoUpstream:
+		return "Unpushed, No Upstream"
+	case FilterUnpushed:
+		return "Ahead of Upstream"
 	}
 	return "All"
 }
 
-// reposToRows converts repos to table rows with status indicators
-func reposToRows(repos []model.Repo) []table.Row {
-	rows := make([]table.Row, 0, len(repos))
-	for _, r := range repos {
-		lastCommit := "N/A"
-		if !r.Status.LastCommit.IsZero() {
-			lastCommit = r.Status.LastCommit.Format("Jan 02 15:04")
+// reposToRows converts repos to table rows of the shown columns with
+// status indicators. Pending repos have been found but their status is
+// not known yet; selected repos are marked. The name and branch runes
+// that match the search text are marked for highlighting. Cells are not
+// truncated; that happens when they are rendered at their final width.
+func (m Model) reposToRows(repos []model.Repo) []repoRow {
+	var sizes map[string]int64
+	if m.diskData != nil && hasColumn(m.columns, "size") {
+		sizes = make(map[string]int64, len(m.diskData.Repos))
+		for _, u := range m.diskData.Repos {
+			sizes[u.Path] = u.TotalSize
 		}
+	}
 
-		// Status indicator with text
-		status := "✓ Clean"
-		if r.Status.IsDirty {
-			status = "● Dirty"
+	rows := make([]repoRow, 0, len(repos))
+	for _, r := range repos {
+		var nameMarks, branchMarks []int
+		if m.query != nil {
+			nameMarks, branchMarks = m.query.Highlights(r)
 		}
 
-		rows = append(rows, table.Row{
-			status,
-			truncateString(r.Name, 18),
-			truncateString(r.Status.Branch, 14),
-			formatNumber(r.Status.Staged),
-			formatNumber(r.Status.Unstaged),
-			formatNumber(r.Status.Untracked),
-			lastCommit,
-		})
+		row := repoRow{
+			cells: make(table.Row, len(m.columns)),
+			marks: make([][]int, len(m.columns)),
+		}
+		for i, col := range m.columns {
+			switch col.key {
+			case "status":
+				row.cells[i] = statusLabel(r.Status)
+				if m.pending[r.Path] {
+					row.cells[i] = "… Scan"
+				}
+			case "name":
+				name := r.Name
+				if r.IsWorktree() {
+					name = "↳ " + name
+				}
+				if m.selected[r.Path] {
+					name = "◆ " + name
+				}
+				row.cells[i] = name
+				row.marks[i] = shiftMarks(nameMarks, len([]rune(name))-len([]rune(r.Name)))
+			case "branch":
+				row.cells[i] = branchLabel(r.Status)
+				if strings.HasPrefix(row.cells[i], r.Status.Branch) {
+					row.marks[i] = branchMarks
+				}
+			case "path":
+				row.cells[i] = displayRepoPath(r.Path)
+			case "upstream":
+				row.cells[i] = orDash(r.Status.Upstream)
+			case "ahead-behind":
+				row.cells[i] = aheadBehindLabel(r.Status)
+			case "staged":
+				row.cells[i] = formatNumber(r.Status.Staged)
+			case "modified":
+				row.cells[i] = formatNumber(r.Status.Unstaged)
+			case "untracked":
+				row.cells[i] = formatNumber(r.Status.Untracked)
+			case "stash":
+				row.cells[i] = formatNumber(r.Status.Stashes)
+			case "remote":
+				row.cells[i] = orDash(model.RemoteSlug(r.Status.Remote))
+			case "size":
+				row.cells[i] = "…"
+				if sizes != nil {
+					row.cells[i] = orDash(formatSize(sizes[r.Path]))
+				}
+			case "last-commit":
+				row.cells[i] = "N/A"
+				if !r.Status.LastCommit.IsZero() {
+					row.cells[i] = r.Status.LastCommit.Format("Jan 02 15:04")
+				}
+			case "author":
+				row.cells[i] = orDash(r.Status.LastAuthor)
+			case "message":
+				row.cells[i] = orDash(r.Status.LastMessage)
+			}
+		}
+		row.path = r.Path
+		rows = append(rows, row)
 	}
 	return rows
 }
 
-// truncateString shortens a string with ellipsis
-func truncateString(s string, maxLen int) string {
-	if len(s) <= maxLen {
-		return s
+// shiftMarks moves marks past a prefix of offset runes
+func shiftMarks(marks []int, offset int) []int {
+	if offset == 0 {
+		return marks
+	}
+	shifted := make([]int, len(marks))
+	for i, n := range marks {
+		shifted[i] = n + offset
 	}
-	return s[:maxLen-1] + "…"
+	return shifted
+}
+
+// aheadBehindLabel returns the Ahead/Behind cell text, e.g. "↑2 ↓5"
+func aheadBehindLabel(s model.RepoStatus) string {
+	var parts []string
+	if s.Ahead > 0 {
+		parts = append(parts, fmt.Sprintf("↑%d", s.Ahead))
+	}
+	if s.Behind > 0 {
+		parts = append(parts, fmt.Sprintf("↓%d", s.Behind))
+	}
+	if len(parts) == 0 {
+		return "—"
+	}
+	return strings.Join(parts, " ")
+}
+
+// formatSize formats a disk size, or returns "" for none
+func formatSize(size int64) string {
+	if size == 0 {
+		return ""
+	}
+	return stats.FormatBytes(size)
+}
+
+// orDash returns s, or "—" when it is empty
+func orDash(s string) string {
+	if s == "" {
+		return "—"
+	}
+	return s
+}
+
+// branchLabel returns the Branch cell text: the branch name marked with
+// its upstream tracking state, or the short HEAD oid when detached
+func branchLabel(s model.RepoStatus) string {
+	switch s.Tracking {
+	case model.TrackingDetached:
+		if len(s.HeadOID) >= 7 {
+			return "➦ " + s.HeadOID[:7]
+		}
+		return s.Branch
+	case model.TrackingNoUpstream:
+		return s.Branch + " ⊘"
+	case model.TrackingGone:
+		return s.Branch + " ✗"
+	}
+
+	label := s.Branch
+	// Only spell out the upstream when it is not the usual origin/<branch>
+	if s.Upstream != "" && s.Upstream != "origin/"+s.Branch {
+		label += "→" + s.Upstream
+	}
+	if s.Ahead > 0 {
+		label += fmt.Sprintf(" ↑%d", s.Ahead)
+	}
+	if s.Behind > 0 {
+		label += fmt.Sprintf(" ↓%d", s.Behind)
+	}
+	return label
+}
+
+// statusLabel returns the Status cell text. A scan error, an operation in
+// progress or unresolved conflicts take precedence over the plain
+// dirty/clean state.
+func statusLabel(s model.RepoStatus) string {
+	switch {
+	case s.ScanError != "":
+		return "✗ Error"
+	case s.Operation != "":
+		return "⚠ " + operationLabel(s.Operation)
+	case s.Conflicts > 0:
+		return fmt.Sprintf("✗ %d Conf", s.Conflicts)
+	case s.IsDirty:
+		return "● Dirty"
+	}
+	return "✓ Clean"
+}
+
+// operationLabel returns a short display name for an in-progress operation
+func operationLabel(op model.Operation) string {
+	switch op {
+	case model.OpRebase:
+		return "Rebase"
+	case model.OpAm:
+		return "AM"
+	case model.OpMerge:
+		return "Merge"
+	case model.OpCherryPick:
+		return "Pick"
+	case model.OpRevert:
+		return "Revert"
+	case model.OpBisect:
+		return "Bisect"
+	}
+	return string(op)
+}
+
+// truncateString shortens a string with ellipsis to at most maxLen
+// display columns, so wide runes such as CJK count twice
+func truncateString(s string, maxLen int) string {
+	return runewidth.Truncate(s, maxLen, "…")
 }
 
 // formatNumber formats a number for display
@@ -362,6 +845,9 @@ func (m *Model) resizeTable() {
 	usedHeight := 12 // Header + Stats + Legend + Help + Padding
 	if m.state == StateSearching {
 		usedHeight += 3 // Search input
+		if m.queryErr != nil {
+			usedHeight++ // Query error
+		}
 	} else if m.searchQuery != "" {
 		usedHeight += 1 // Search badge
 	}
@@ -371,4 +857,5 @@ func (m *Model) resizeTable() {
 		h = 1
 	}
 	m.table.SetHeight(h)
+	m.scrollTable()
 }
@@ -2,9 +2,11 @@ package tui
 
 import (
 	"fmt"
+	"os"
 	"strings"
 	"time"
 
+	"github.com/Bharath-code/git-scope/internal/model"
 	"github.com/Bharath-code/git-scope/internal/stats"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -17,6 +19,7 @@ const (
 	PanelGrass
 	PanelDisk
 	PanelTimeline
+	PanelDetail
 )
 
 // Heatmap color palette (GitHub-style green gradient)
@@ -53,17 +56,11 @@ var (
 
 // renderSplitPane renders a split-pane layout with table on left and panel on right
 func renderSplitPane(leftContent, rightContent string, totalWidth int) string {
-	// 60% for table, 40% for panel
-	leftWidth := int(float64(totalWidth) * 0.58)
-	rightWidth := totalWidth - leftWidth - 3 // Account for borders/gaps
-
-	if rightWidth < 20 {
-		rightWidth = 20
-		leftWidth = totalWidth - rightWidth - 3
-	}
+	leftWidth, rightWidth := splitPaneWidths(totalWidth)
 
+	// Cut table rows at the pane edge rather than wrapping them
 	leftPane := lipgloss.NewStyle().
-		Width(leftWidth).
+		MaxWidth(leftWidth).
 		Render(leftContent)
 
 	// Use active border style for panel (Tuimorphic)
@@ -74,6 +71,20 @@ func renderSplitPane(leftContent, rightContent string, totalWidth int) string {
 	return lipgloss.JoinHorizontal(lipgloss.Top, leftPane, " ", rightPane)
 }
 
+// splitPaneWidths returns the widths of the table and panel of a split
+// pane totalWidth wide
+func splitPaneWidths(totalWidth int) (left, right int) {
+	// 60% for table, 40% for panel
+	left = int(float64(totalWidth) * 0.58)
+	right = totalWidth - left - 3 // Account for borders/gaps
+
+	if right < 20 {
+		right = 20
+		left = totalWidth - right - 3
+	}
+	return left, right
+}
+
 // renderGrassPanel renders the contribution heatmap panel
 func renderGrassPanel(data *stats.ContributionData, width, height int) string {
 	if data == nil {
@@ -195,6 +206,8 @@ func getPanelHelp(panel PanelType) string {
 		return helpItem("d", "close") + " • " + helpItem("esc", "close")
 	case PanelTimeline:
 		return helpItem("t", "close") + " • " + helpItem("esc", "close")
+	case PanelDetail:
+		return helpItem("i", "close") + " • " + helpItem("esc", "close")
 	default:
 		return ""
 	}
@@ -454,3 +467,210 @@ func timelineDayStyle(dayLabel string) lipgloss.Style {
 		return timelineOlderStyle
 	}
 }
+
+// Detail panel styles
+var (
+	detailSectionStyle  = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#A78BFA"))
+	detailStagedStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("#22c55e"))
+	detailUnstagedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#eab308"))
+	detailConflictStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#ef4444")).Bold(true)
+	detailHashStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B"))
+	detailCurrentStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#f0f6fc")).Bold(true)
+)
+
+// renderDetailPanel renders the changed files, branches, recent commits
+// and stashes of the highlighted repo
+func renderDetailPanel(repo *model.Repo, data *model.RepoDetails, err error, width, height int) string {
+	if repo == nil {
+		return panelMutedStyle.Render("No repo selected.")
+	}
+
+	var b strings.Builder
+	b.WriteString(panelTitleStyle.Render("🔎 " + repo.Name))
+	b.WriteString("\n")
+	b.WriteString(panelSubtitleStyle.Render(truncateString(displayRepoPath(repo.Path), width-2)))
+	b.WriteString("\n\n")
+
+	if err != nil {
+		b.WriteString(panelMutedStyle.Render("Could not read repo: " + err.Error()))
+		return b.String()
+	}
+	if data == nil {
+		b.WriteString(panelMutedStyle.Render("Loading details..."))
+		return b.String()
+	}
+
+	// Share the rows between sections: a few each, then the rest to
+	// files, commits, branches and stashes in that order
+	headerRows := 3 + 3*2
+	if len(data.Stashes) > 0 {
+		headerRows += 2
+	}
+	limits := allocateRows(height-headerRows, []int{
+		len(data.Files), len(data.Commits), len(data.Branches), len(data.Stashes),
+	})
+	fileRows, commitRows, branchRows, stashRows := limits[0], limits[1], limits[2], limits[3]
+
+	lineWidth := width - 2
+
+	// Changed files
+	b.WriteString(detailSectionStyle.Render(fmt.Sprintf("Changes (%d)", len(data.Files))))
+	b.WriteString("\n")
+	if len(data.Files) == 0 {
+		b.WriteString(panelMutedStyle.Render("  Working tree clean"))
+		b.WriteString("\n")
+	}
+	for i, f := range data.Files {
+		if i == fileRows-1 && len(data.Files) > fileRows {
+			b.WriteString(panelMutedStyle.Render(fmt.Sprintf("  ... and %d more", len(data.Files)-i)))
+			b.WriteString("\n")
+			break
+		}
+		b.WriteString("  " + fileChangeCode(f) + " " + truncateString(fileChangePath(f), lineWidth-5))
+		b.WriteString("\n")
+	}
+
+	// Local branches
+	b.WriteString("\n")
+	b.WriteString(detailSectionStyle.Render(fmt.Sprintf("Branches (%d)", len(data.Branches))))
+	b.WriteString("\n")
+	for i, br := range data.Branches {
+		if i == branchRows-1 && len(data.Branches) > branchRows {
+			b.WriteString(panelMutedStyle.Render(fmt.Sprintf("  ... and %d more", len(data.Branches)-i)))
+			b.WriteString("\n")
+			break
+		}
+		b.WriteString(renderBranchLine(br, lineWidth))
+		b.WriteString("\n")
+	}
+
+	// Recent commits
+	b.WriteString("\n")
+	b.WriteString(detailSectionStyle.Render("Recent commits"))
+	b.WriteString("\n")
+	if len(data.Commits) == 0 {
+		b.WriteString(panelMutedStyle.Render("  No commits yet"))
+		b.WriteString("\n")
+	}
+	for i, c := range data.Commits {
+		if i == commitRows {
+			break
+		}
+		age := ""
+		if !c.When.IsZero() {
+			age = " · " + formatAge(time.Since(c.When))
+		}
+		subject := truncateString(c.Subject, lineWidth-len(c.Hash)-len([]rune(age))-3)
+		b.WriteString("  " + detailHashStyle.Render(c.Hash) + " " + subject + panelMutedStyle.Render(age))
+		b.WriteString("\n")
+	}
+
+	// Stashes
+	if len(data.Stashes) > 0 {
+		b.WriteString("\n")
+		b.WriteString(detailSectionStyle.Render(fmt.Sprintf("Stashes (%d)", len(data.Stashes))))
+		b.WriteString("\n")
+		for i, st := range data.Stashes {
+			if i == stashRows-1 && len(data.Stashes) > stashRows {
+				b.WriteString(panelMutedStyle.Render(fmt.Sprintf("  ... and %d more", len(data.Stashes)-i)))
+				b.WriteString("\n")
+				break
+			}
+			b.WriteString("  " + panelSubtitleStyle.Render(st.Ref) + " " + truncateString(st.Subject, lineWidth-len(st.Ref)-3))
+			b.WriteString("\n")
+		}
+	}
+
+	return b.String()
+}
+
+// allocateRows splits avail rows between sections that want the given
+// numbers of rows. Every section first gets up to 3 rows, then the rest
+// goes to the sections in order. Each section gets at least 1 row.
+func allocateRows(avail int, wants []int) []int {
+	const minRows = 3
+	rows := make([]int, len(wants))
+	for i, want := range wants {
+		rows[i] = want
+		if rows[i] > minRows {
+			rows[i] = minRows
+		}
+		if rows[i] < 1 {
+			rows[i] = 1
+		}
+		avail -= rows[i]
+	}
+	for i, want := range wants {
+		if avail <= 0 {
+			break
+		}
+		extra := want - rows[i]
+		if extra > avail {
+			extra = avail
+		}
+		if extra > 0 {
+			rows[i] += extra
+			avail -= extra
+		}
+	}
+	return rows
+}
+
+// fileChangeCode renders the two-letter porcelain status of a file,
+// coloring the staged and unstaged halves
+func fileChangeCode(f model.FileChange) string {
+	switch {
+	case f.IsConflict():
+		return detailConflictStyle.Render(string([]byte{f.Index, f.Worktree}))
+	case f.IsUntracked():
+		return panelMutedStyle.Render("??")
+	}
+	return detailStagedStyle.Render(string(f.Index)) + detailUnstagedStyle.Render(string(f.Worktree))
+}
+
+// fileChangePath returns the path of a file change, showing the source
+// of renames
+func fileChangePath(f model.FileChange) string {
+	if f.OrigPath != "" {
+		return f.OrigPath + " → " + f.Path
+	}
+	return f.Path
+}
+
+// renderBranchLine renders a local branch with its upstream state
+func renderBranchLine(br model.BranchInfo, width int) string {
+	marker, name := "  ", br.Name
+	if br.Current {
+		marker = "* "
+		name = detailCurrentStyle.Render(br.Name)
+	}
+
+	var state string
+	switch {
+	case br.Upstream == "":
+		state = "⊘"
+	case br.Gone:
+		state = "✗ " + br.Upstream
+	default:
+		parts := []string{}
+		if br.Ahead > 0 {
+			parts = append(parts, fmt.Sprintf("↑%d", br.Ahead))
+		}
+		if br.Behind > 0 {
+			parts = append(parts, fmt.Sprintf("↓%d", br.Behind))
+		}
+		parts = append(parts, "→ "+br.Upstream)
+		state = strings.Join(parts, " ")
+	}
+	state = truncateString(state, width-len([]rune(br.Name))-4)
+	return marker + name + " " + panelMutedStyle.Render(state)
+}
+
+// displayRepoPath shortens a path inside the home directory to ~/...
+func displayRepoPath(path string) string {
+	home, err := os.UserHomeDir()
+	if err != nil || !strings.HasPrefix(path, home+string(os.PathSeparator)) {
+		return path
+	}
+	return "~" + strings.TrimPrefix(path, home)
+}
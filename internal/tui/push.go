@@ -0,0 +1,140 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Bharath-code/git-scope/internal/gitops"
+	"github.com/Bharath-code/git-scope/internal/model"
+	"github.com/Bharath-code/git-scope/internal/scan"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// confirmPush asks to confirm a push of the given repos, or explains on
+// the status line why none of them can be pushed
+func (m *Model) confirmPush(targets []model.Repo) {
+	if m.opName != "" {
+		m.statusMsg = fmt.Sprintf("⏳ Wait for the running %s to finish", m.opName)
+		return
+	}
+	if countPushable(targets) == 0 {
+		if len(targets) == 1 {
+			m.statusMsg = fmt.Sprintf("Can't push %s: %s", targets[0].Name, gitops.PushBlocker(targets[0].Status))
+		} else {
+			m.statusMsg = fmt.Sprintf("Nothing to push in %d repos", len(targets))
+		}
+		return
+	}
+	m.pushTargets = targets
+	m.state = StateConfirmPush
+}
+
+// handleConfirmPushMode handles key events while a push waits for
+// confirmation
+func (m Model) handleConfirmPushMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "enter":
+		targets := m.pushTargets
+		m.state = StateReady
+		m.pushTargets = nil
+		m.statusMsg = ""
+		cmd := m.startOp("push", targets, gitops.PushOp(scan.OptionsFromConfig(m.cfg)))
+		m.opShowResults = len(targets) > 1
+		return m, cmd
+	case "n", "esc", "q":
+		m.state = StateReady
+		m.pushTargets = nil
+		m.statusMsg = "Push cancelled"
+		return m, nil
+	case "ctrl+c":
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+// countPushable counts the repos PushBlocker lets through
+func countPushable(repos []model.Repo) int {
+	n := 0
+	for _, r := range repos {
+		if gitops.PushBlocker(r.Status) == "" {
+			n++
+		}
+	}
+	return n
+}
+
+// pushPlan describes what a push would do for a repo
+func pushPlan(s model.RepoStatus) string {
+	commits := "1 commit"
+	if s.Ahead != 1 {
+		commits = fmt.Sprintf("%d commits", s.Ahead)
+	}
+	if s.Tracking == model.TrackingNoUpstream {
+		return fmt.Sprintf("%s · %s → new upstream", s.Branch, commits)
+	}
+	return fmt.Sprintf("%s · %s → %s", s.Branch, commits, s.Upstream)
+}
+
+// renderConfirmPush renders the push confirmation, listing what each
+// repo would push and why the others are skipped
+func (m Model) renderConfirmPush() string {
+	var b strings.Builder
+
+	b.WriteString(compactLogo())
+	b.WriteString("\n\n")
+
+	modalStyle := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#7C3AED")).
+		Padding(1, 2).
+		Width(70)
+
+	label := targetsLabel(m.pushTargets)
+	if len(m.pushTargets) > 1 {
+		label = fmt.Sprintf("%d of %s", countPushable(m.pushTargets), label)
+	}
+	title := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#A78BFA")).
+		Bold(true).
+		Render("⇡ Push " + label)
+
+	width := 0
+	for _, r := range m.pushTargets {
+		if len(r.Name) > width {
+			width = len(r.Name)
+		}
+	}
+	if width > 24 {
+		width = 24
+	}
+
+	maxLines := m.height - 16
+	if maxLines < 3 {
+		maxLines = 3
+	}
+	okStyle := lipgloss.NewStyle().Foreground(cleanColor)
+	skipStyle := lipgloss.NewStyle().Foreground(mutedColor)
+	var lines strings.Builder
+	for i, r := range m.pushTargets {
+		if i == maxLines-1 && len(m.pushTargets) > maxLines {
+			lines.WriteString(skipStyle.Render(fmt.Sprintf("… and %d more", len(m.pushTargets)-i)) + "\n")
+			break
+		}
+		name := fmt.Sprintf("%-*s", width, truncateString(r.Name, width))
+		if reason := gitops.PushBlocker(r.Status); reason != "" {
+			lines.WriteString(skipStyle.Render("– "+name+"  skip: "+reason) + "\n")
+			continue
+		}
+		lines.WriteString(okStyle.Render("⇡") + " " + name + "  " + truncateString(pushPlan(r.Status), 60-width) + "\n")
+	}
+
+	footer := lipgloss.NewStyle().
+		Foreground(mutedColor).
+		Render("\ny/Enter = push   n/Esc = cancel")
+
+	b.WriteString(modalStyle.Render(title + "\n\n" + lines.String() + footer))
+	b.WriteString("\n\n")
+	b.WriteString(m.renderHelp())
+	return b.String()
+}
@@ -0,0 +1,109 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/Bharath-code/git-scope/internal/model"
+	tea "github.com/charmbracelet/bubbletea"
+	"mvdan.cc/sh/v3/shell"
+)
+
+// pathPlaceholder is replaced by the repo path in the terminal command
+const pathPlaceholder = "{path}"
+
+// shellClosedMsg is sent when the shell started in a repo exits. err is
+// only set when the shell could not be run.
+type shellClosedMsg struct {
+	repo model.Repo
+	err  error
+}
+
+// terminalOpenedMsg is sent once the terminal command was started
+type terminalOpenedMsg struct {
+	repo model.Repo
+	err  error
+}
+
+// userShell returns the interactive shell of the user
+func userShell() string {
+	if runtime.GOOS == "windows" {
+		if comspec := os.Getenv("COMSPEC"); comspec != "" {
+			return comspec
+		}
+		return "cmd.exe"
+	}
+	if sh := os.Getenv("SHELL"); sh != "" {
+		return sh
+	}
+	return "/bin/sh"
+}
+
+// openShellCmd suspends the TUI and runs the user's shell in the repo
+// until it exits
+func openShellCmd(repo model.Repo) tea.Cmd {
+	c := exec.Command(userShell())
+	c.Dir = repo.Path
+	c.Env = append(os.Environ(),
+		"GIT_SCOPE_REPO="+repo.Name,
+		"GIT_SCOPE_PATH="+repo.Path,
+	)
+	return tea.ExecProcess(c, func(err error) tea.Msg {
+		// A shell exits with the status of its last command, which says
+		// nothing about the shell itself
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			err = nil
+		}
+		return shellClosedMsg{repo: repo, err: err}
+	})
+}
+
+// terminalCommand builds the configured terminal command for a repo.
+// Every {path} is replaced by the repo path; without one, the path is
+// appended as the last argument.
+func terminalCommand(terminal string, repo model.Repo) (*exec.Cmd, error) {
+	fields, err := shell.Fields(terminal, nil)
+	if err != nil || len(fields) == 0 {
+		return nil, fmt.Errorf("invalid terminal command: '%s'", terminal)
+	}
+
+	placed := false
+	for i, f := range fields {
+		if strings.Contains(f, pathPlaceholder) {
+			fields[i] = strings.ReplaceAll(f, pathPlaceholder, repo.Path)
+			placed = true
+		}
+	}
+	if !placed {
+		fields = append(fields, repo.Path)
+	}
+
+	if _, err := exec.LookPath(fields[0]); err != nil {
+		return nil, fmt.Errorf("terminal '%s' not found in PATH", fields[0])
+	}
+	c := exec.Command(fields[0], fields[1:]...)
+	c.Dir = repo.Path
+	return c, nil
+}
+
+// openTerminalCmd starts the configured terminal command for a repo
+// without suspending the TUI
+func openTerminalCmd(terminal string, repo model.Repo) tea.Cmd {
+	return func() tea.Msg {
+		c, err := terminalCommand(terminal, repo)
+		if err != nil {
+			return terminalOpenedMsg{repo: repo, err: err}
+		}
+		if err := c.Start(); err != nil {
+			return terminalOpenedMsg{repo: repo, err: err}
+		}
+		// Reap the process whenever the terminal exits
+		go func() { _ = c.Wait() }()
+		return terminalOpenedMsg{repo: repo}
+	}
+}
@@ -91,6 +91,70 @@ var (
 			Padding(0, 1).
 			Bold(true)
 
+	inProgressBadgeStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#FFFFFF")).
+				Background(errorColor).
+				Padding(0, 1).
+				Bold(true)
+
+	stashBadgeStyle = lipgloss.NewStyle().
+			Foreground(textPrimary).
+			Background(bgSurface).
+			Padding(0, 1)
+
+	queryErrorStyle = lipgloss.NewStyle().
+			Foreground(errorColor)
+
+	queryErrorTermStyle = lipgloss.NewStyle().
+				Foreground(errorColor).
+				Underline(true).
+				Bold(true)
+
+	unpushedBadgeStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#000000")).
+				Background(lipgloss.Color("#F472B6")).
+				Padding(0, 1)
+
+	scanBadgeStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#000000")).
+			Background(secondaryColor).
+			Padding(0, 1)
+
+	cacheBadgeStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#000000")).
+			Background(accentColor).
+			Padding(0, 1)
+
+	watchBadgeStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#000000")).
+			Background(lipgloss.Color("#60A5FA")).
+			Padding(0, 1)
+
+	selectBadgeStyle = lipgloss.NewStyle().
+				Foreground(textPrimary).
+				Background(primaryDim).
+				Padding(0, 1)
+
+	viewBadgeStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#000000")).
+			Background(lipgloss.Color("#22d3ee")).
+			Padding(0, 1).
+			Bold(true)
+
+	groupBadgeStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFFFFF")).
+			Background(lipgloss.Color("#0EA5E9")).
+			Padding(0, 1)
+
+	groupHeaderStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#A78BFA")).
+				Bold(true)
+
+	opBadgeStyle = lipgloss.NewStyle().
+			Foreground(textPrimary).
+			Background(primaryColor).
+			Padding(0, 1)
+
 	// Table styles - bordered container
 	tableContainerStyle = lipgloss.NewStyle().
 				BorderStyle(lipgloss.RoundedBorder()).
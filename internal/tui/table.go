@@ -0,0 +1,347 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+)
+
+// repoColumn is a column the repo table can show
+type repoColumn struct {
+	key   string // name of the column in the config
+	title string
+	width int // narrowest width
+	grow  int // share of the spare width the column takes, 0 to stay fixed
+}
+
+// repoColumns are all the columns of the repo table
+var repoColumns = []repoColumn{
+	{"status", "Status", 9, 0},
+	{"name", "Repository", 18, 3},
+	{"branch", "Branch", 18, 2},
+	{"path", "Path", 20, 3},
+	{"upstream", "Upstream", 16, 1},
+	{"ahead-behind", "Ahead/Behind", 12, 0},
+	{"staged", "Staged", 6, 0},
+	{"modified", "Modified", 8, 0},
+	{"untracked", "Untracked", 9, 0},
+	{"stash", "Stash", 5, 0},
+	{"remote", "Remote", 20, 2},
+	{"size", "Size", 9, 0},
+	{"last-commit", "Last Commit", 14, 0},
+	{"author", "Author", 14, 1},
+	{"message", "Message", 24, 4},
+}
+
+// defaultColumns are the columns shown unless the config picks others
+var defaultColumns = []string{"status", "name", "branch", "staged", "modified", "untracked", "stash", "last-commit"}
+
+// parseColumns looks up columns by key. No keys means the fallback.
+func parseColumns(keys []string, fallback []repoColumn) ([]repoColumn, error) {
+	if len(keys) == 0 {
+		return fallback, nil
+	}
+	cols := make([]repoColumn, 0, len(keys))
+	for _, key := range keys {
+		col, ok := findColumn(key)
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q (want %s)", key, strings.Join(columnKeys(), ", "))
+		}
+		cols = append(cols, col)
+	}
+	return cols, nil
+}
+
+// findColumn returns the column with the given key
+func findColumn(key string) (repoColumn, bool) {
+	for _, col := range repoColumns {
+		if col.key == key {
+			return col, true
+		}
+	}
+	return repoColumn{}, false
+}
+
+// columnKeys lists the keys of all columns
+func columnKeys() []string {
+	keys := make([]string, len(repoColumns))
+	for i, col := range repoColumns {
+		keys[i] = col.key
+	}
+	return keys
+}
+
+// hasColumn reports whether the column with the given key is shown
+func hasColumn(cols []repoColumn, key string) bool {
+	for _, col := range cols {
+		if col.key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// loadSizesCmd loads the disk usage of the repos when the size column is
+// shown and it hasn't been loaded yet
+func (m Model) loadSizesCmd() tea.Cmd {
+	if m.diskData != nil || len(m.repos) == 0 || !hasColumn(m.columns, "size") {
+		return nil
+	}
+	return loadDiskDataCmd(m.repos)
+}
+
+// tableColumns converts columns for the table model
+func tableColumns(cols []repoColumn) []table.Column {
+	tcols := make([]table.Column, len(cols))
+	for i, col := range cols {
+		tcols[i] = table.Column{Title: col.title, Width: col.width}
+	}
+	return tcols
+}
+
+// layoutColumns returns the columns with the growable ones widened to
+// fill width display columns. Columns that don't fit are left as they
+// are, to be cut off at the edge.
+func layoutColumns(cols []repoColumn, width int) []repoColumn {
+	spare, grow := width, 0
+	for _, col := range cols {
+		spare -= col.width + 2 // cell padding
+		grow += col.grow
+	}
+	if spare <= 0 || grow == 0 {
+		return cols
+	}
+
+	laid := make([]repoColumn, len(cols))
+	copy(laid, cols)
+	given := 0
+	for i := range laid {
+		extra := spare * laid[i].grow / grow
+		laid[i].width += extra
+		given += extra
+	}
+	// Hand out what rounding left over, left to right
+	for i := 0; given < spare; i = (i + 1) % len(laid) {
+		if laid[i].grow > 0 {
+			laid[i].width++
+			given++
+		}
+	}
+	return laid
+}
+
+// repoTableStyles are the styles of the repo table
+var repoTableStyles = newRepoTableStyles()
+
+// Search match highlighting in table cells
+var (
+	matchStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#22d3ee")).
+			Bold(true).
+			Underline(true)
+	selectedMatchStyle = repoTableStyles.Selected.Copy().
+				Underline(true)
+)
+
+// newRepoTableStyles returns the table styles with strong highlighting
+func newRepoTableStyles() table.Styles {
+	s := table.DefaultStyles()
+	s.Header = s.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("#7C3AED")).
+		BorderBottom(true).
+		Bold(true).
+		Foreground(lipgloss.Color("#FFFFFF")).
+		Background(lipgloss.Color("#7C3AED")).
+		Padding(0, 1)
+
+	// Strong row highlighting
+	s.Selected = s.Selected.
+		Foreground(lipgloss.Color("#000000")).
+		Background(lipgloss.Color("#A78BFA")).
+		Bold(true)
+
+	s.Cell = s.Cell.
+		Padding(0, 1)
+	return s
+}
+
+// repoRow is a table row along with the runes of each cell that matched
+// the search, so they can be highlighted. A row with a group is the
+// header of that group rather than a repo.
+type repoRow struct {
+	cells table.Row
+	marks [][]int // per cell, rune indexes in ascending order
+	path  string  // path of the repo
+	// Group header
+	group   string // name of the group
+	label   string // name of the group for display
+	summary string // counts of the group
+	folded  bool
+}
+
+// setTableRows fills the table with the repos of the current page, or
+// with all groups when grouping
+func (m *Model) setTableRows() {
+	if m.grouped() {
+		m.rows = m.groupRows()
+	} else {
+		m.rows = m.reposToRows(m.getCurrentPageRepos())
+	}
+	cells := make([]table.Row, len(m.rows))
+	for i, r := range m.rows {
+		cells[i] = r.cells
+	}
+	m.table.SetRows(cells)
+	m.scrollTable()
+}
+
+// scrollTable moves the first visible row just enough to keep the cursor
+// in view, like the table itself does
+func (m *Model) scrollTable() {
+	m.tableTop = visibleTop(m.tableTop, m.table.Cursor(), m.table.Height(), len(m.rows))
+}
+
+// visibleTop returns the first row to show so that cursor is one of the
+// height rows shown, scrolling as little as possible from top
+func visibleTop(top, cursor, height, rows int) int {
+	if cursor < top {
+		top = cursor
+	}
+	if cursor >= top+height {
+		top = cursor - height + 1
+	}
+	if top > rows-height {
+		top = rows - height
+	}
+	if top < 0 {
+		top = 0
+	}
+	return top
+}
+
+// tableHeight returns how many rows of the table fit on screen. The
+// status message and star nudge come and go without a resize, so
+// resizeTable leaves a single line for them and the rows they need
+// beyond that are only given up when rendering.
+func (m Model) tableHeight() int {
+	extra := 0
+	if m.statusMsg != "" {
+		extra += lipgloss.Height(statusStyle.Render("→ " + m.statusMsg))
+	}
+	if m.showStarNudge {
+		extra += lipgloss.Height(m.renderStarNudge())
+	}
+	h := m.table.Height()
+	if extra > 1 {
+		h -= extra - 1
+	}
+	if h < 1 {
+		h = 1
+	}
+	return h
+}
+
+// renderTable renders the repo table width display columns wide, with
+// the columns widened to fill it. It looks like the table's own View,
+// which can't style parts of a cell, with search matches highlighted.
+func (m Model) renderTable(width int) string {
+	cols := layoutColumns(m.columns, width)
+	total := 0
+	headers := make([]string, len(cols))
+	for i, col := range cols {
+		cell := lipgloss.NewStyle().Width(col.width).MaxWidth(col.width).Inline(true).
+			Render(runewidth.Truncate(col.title, col.width, "…"))
+		headers[i] = repoTableStyles.Header.Render(cell)
+		total += col.width + 2
+	}
+
+	height := m.tableHeight()
+	cursor := m.table.Cursor()
+	top := visibleTop(m.tableTop, cursor, height, len(m.rows))
+	lines := make([]string, 0, height)
+	for i := top; i < len(m.rows) && len(lines) < height; i++ {
+		if m.rows[i].group != "" {
+			lines = append(lines, renderGroupHeader(m.rows[i], total, i == cursor))
+			continue
+		}
+		lines = append(lines, renderTableRow(m.rows[i], cols, i == cursor))
+	}
+	for len(lines) < height {
+		lines = append(lines, strings.Repeat(" ", total))
+	}
+
+	out := lipgloss.JoinHorizontal(lipgloss.Left, headers...) + "\n" + strings.Join(lines, "\n")
+	if width > 0 && total > width {
+		// Too many columns for the screen; cut them off at the edge
+		out = lipgloss.NewStyle().MaxWidth(width).Render(out)
+	}
+	return out
+}
+
+// renderTableRow renders one row, styling every cell separately so the
+// selected row keeps its background around highlighted runes
+func renderTableRow(row repoRow, cols []repoColumn, selected bool) string {
+	base, mark := lipgloss.NewStyle(), matchStyle
+	if selected {
+		base, mark = repoTableStyles.Selected, selectedMatchStyle
+	}
+
+	var b strings.Builder
+	for i, value := range row.cells {
+		if i >= len(cols) {
+			break
+		}
+		var marks []int
+		if i < len(row.marks) {
+			marks = row.marks[i]
+		}
+		b.WriteString(renderTableCell(value, marks, cols[i].width, base, mark))
+	}
+	return b.String()
+}
+
+// renderTableCell renders a cell padded or truncated to width display
+// columns, with the runes at marks in the mark style
+func renderTableCell(value string, marks []int, width int, base, mark lipgloss.Style) string {
+	text := runewidth.Truncate(value, width, "…")
+	runes := []rune(text)
+	limit := len(runes)
+	if text != value {
+		// Don't highlight the ellipsis
+		limit--
+	}
+
+	marked := make([]bool, len(runes))
+	for _, i := range marks {
+		if i >= 0 && i < limit {
+			marked[i] = true
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(base.Render(" "))
+	for start := 0; start < len(runes); {
+		// Style runs of runes that are all marked or all unmarked at once
+		end := start + 1
+		for end < len(runes) && marked[end] == marked[start] {
+			end++
+		}
+		style := base
+		if marked[start] {
+			style = mark
+		}
+		b.WriteString(style.Render(string(runes[start:end])))
+		start = end
+	}
+	pad := width - runewidth.StringWidth(text)
+	if pad < 0 {
+		pad = 0
+	}
+	b.WriteString(base.Render(strings.Repeat(" ", pad+1)))
+	return b.String()
+}
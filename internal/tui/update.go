@@ -1,10 +1,15 @@
 package tui
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os/exec"
+	"strings"
 
 	"github.com/Bharath-code/git-scope/internal/browser"
+	"github.com/Bharath-code/git-scope/internal/gitops"
+	"github.com/Bharath-code/git-scope/internal/gitstatus"
 	"github.com/Bharath-code/git-scope/internal/model"
 	"github.com/Bharath-code/git-scope/internal/nudge"
 	"github.com/Bharath-code/git-scope/internal/scan"
@@ -26,6 +31,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.width = msg.Width
 		m.height = msg.Height
 		m.resizeTable()
+		m.resizeResults()
+		m.resizeDiff()
 
 	case spinner.TickMsg:
 		// Update spinner during loading
@@ -34,49 +41,172 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			cmds = append(cmds, cmd)
 		}
 
-	case scanCompleteMsg:
+	case cachedReposMsg:
+		if msg.id != m.scanID {
+			return m, nil
+		}
 		m.repos = msg.repos
+		m.cachedAt = msg.cachedAt
 		m.state = StateReady
 		m.resetPage()
+		m.resizeTable()
 		m.updateTable()
+		m.statusMsg = fmt.Sprintf("✓ Loaded %d repos from cache, refreshing...", len(msg.repos))
+		m.revalidating = true
 
-		// Show helpful message if no repos found
-		if len(msg.repos) == 0 {
-			m.statusMsg = "⚠️  No git repos found in configured directories. Press 'r' to rescan or run 'git-scope init' to configure."
-		} else if msg.fromCache {
-			m.statusMsg = fmt.Sprintf("✓ Loaded %d repos from cache", len(msg.repos))
-		} else {
-			m.statusMsg = fmt.Sprintf("✓ Found %d repos", len(msg.repos))
+		// Revalidate in the background with the same scan context,
+		// querying only repos that changed since they were cached
+		return m, scanReposCmd(m.scanCtx, m.scanID, m.cfg, true)
+
+	case scanProgressMsg:
+		// Drop events from a scan that has since been restarted
+		if msg.id != m.scanID {
+			return m, nil
 		}
-		return m, nil
 
-	case scanErrorMsg:
-		m.state = StateError
-		m.err = msg.err
-		return m, nil
+		m.applyScanEvents(msg.events)
+		if m.state == StateLoading && len(m.repos) > 0 {
+			// First results are in: make the table usable right away
+			m.state = StateReady
+			m.resetPage()
+			m.resizeTable()
+		}
 
-	case workspaceScanCompleteMsg:
-		m.repos = msg.repos
-		m.state = StateReady
-		m.resetPage()
-		m.updateTable()
+		if !msg.done {
+			m.refreshTable()
+			return m, waitScanEventsCmd(msg.id, msg.ch)
+		}
 
-		// Show helpful message about switched workspace
-		if len(msg.repos) == 0 {
-			m.statusMsg = fmt.Sprintf("⚠️  No git repos found in %s", msg.workspacePath)
+		refreshed := m.revalidating
+		m.finishScan()
+		if m.state == StateLoading {
+			m.state = StateReady
+			m.resetPage()
+		}
+		m.refreshTable()
+
+		cmd := m.scanFinished(refreshed)
+		if m.watchEnabled && len(m.repos) > 0 {
+			// Watch the repos this scan found
+			cmd = tea.Batch(cmd, startWatchCmd(m.repos, m.cfg))
+		}
+		return m, cmd
+
+	case watchStartedMsg:
+		if msg.err != nil {
+			m.statusMsg = "❌ Watch mode unavailable: " + msg.err.Error()
+			return m, nil
+		}
+		if !m.watchEnabled {
+			msg.watcher.Close()
+			return m, nil
+		}
+		m.stopWatching()
+		m.watcher = msg.watcher
+		return m, waitWatchCmd(msg.watcher)
+
+	case repoChangedMsg:
+		// Drop changes reported by a watcher that has been replaced
+		if msg.watcher != m.watcher {
+			return m, nil
+		}
+		next := waitWatchCmd(msg.watcher)
+		if repo, ok := m.findRepo(msg.path); ok {
+			return m, tea.Batch(refreshRepoCmd(repo, m.cfg), next)
+		}
+		return m, next
+
+	case repoRefreshedMsg:
+		if m.updateRepo(msg.repo) {
+			m.refreshTable()
+		}
+		if m.inDiffView() && msg.repo.Path == m.diffRepo.Path {
+			m.diffRepo = msg.repo
+			return m, m.reloadDiff()
+		}
+		if m.activePanel == PanelDetail && msg.repo.Path == m.detailPath {
+			return m, m.reloadDetail(msg.repo.Path)
+		}
+		return m, m.syncDetail()
+
+	case detailLoadedMsg:
+		// Drop details of a repo the cursor has already left
+		if msg.path != m.detailPath {
+			return m, nil
+		}
+		m.detailData = msg.data
+		m.detailErr = msg.err
+		return m, nil
+
+	case changeDoneMsg:
+		m.changeBusy = false
+		if msg.err != nil {
+			m.statusMsg = "❌ " + msg.err.Error()
 		} else {
-			m.statusMsg = fmt.Sprintf("✓ Switched to %s (%d repos)", msg.workspacePath, len(msg.repos))
+			m.statusMsg = "✓ " + msg.done
+		}
+		// Re-query just this repo; its row and the diff view follow
+		return m, refreshRepoCmd(msg.repo, m.cfg)
 
-			// Trigger star nudge after successful workspace switch
-			if nudge.ShouldShowNudge() && !m.nudgeShownThisSession {
-				m.showStarNudge = true
-				m.nudgeShownThisSession = true
-				nudge.MarkShown()
-			}
+	case diffFilesLoadedMsg:
+		if !m.inDiffView() || msg.path != m.diffRepo.Path {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.diffErr = msg.err
+			m.diffLoaded = true
+			m.setDiffContent()
+			return m, nil
+		}
+		return m, m.setDiffFiles(msg.files)
+
+	case diffLoadedMsg:
+		// Drop diffs of a file or mode the view has since left
+		f, ok := m.diffFile()
+		if !m.inDiffView() || msg.path != m.diffRepo.Path || !ok ||
+			msg.file != f.Path || msg.staged != m.diffStaged {
+			return m, nil
+		}
+		m.diffText = msg.text
+		m.diffErr = msg.err
+		m.diffLoaded = true
+		m.setDiffContent()
+		if key := diffKey(msg.file, msg.staged); key != m.diffShown {
+			m.diffShown = key
+			m.diffView.GotoTop()
 		}
 		return m, nil
 
-	case workspaceScanErrorMsg:
+	case opProgressMsg:
+		// Drop results of an operation that has been replaced
+		if msg.id != m.opID || m.opName == "" {
+			return m, nil
+		}
+		if msg.done {
+			showResults := m.opShowResults
+			m.statusMsg = m.finishOp()
+			if showResults && m.state == StateReady {
+				m.openResults()
+			} else if !strings.HasPrefix(m.statusMsg, "✓") {
+				m.statusMsg += " · R for details"
+			}
+			return m, nil
+		}
+
+		m.opResults = append(m.opResults, msg.result)
+		var skip *gitops.SkipError
+		if msg.result.Err == nil || errors.As(msg.result.Err, &skip) {
+			if m.updateRepo(msg.result.Repo) {
+				m.refreshTable()
+			}
+		}
+		return m, waitOpCmd(msg.id, msg.ch)
+
+	case scanErrorMsg:
+		// A scan aborted by a newer one or by quitting is not an error
+		if errors.Is(msg.err, context.Canceled) {
+			return m, nil
+		}
 		m.state = StateError
 		m.err = msg.err
 		return m, nil
@@ -95,7 +225,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
-		args := append(fields[1:], msg.path)
+		args := append(fields[1:], msg.paths...)
 		c := exec.Command(fields[0], args...)
 		return m, tea.ExecProcess(c, func(err error) tea.Msg {
 			if err != nil {
@@ -110,7 +240,35 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		} else {
 			m.statusMsg = ""
 		}
-		return m, scanReposCmd(m.cfg)
+		// Refresh in the background, keeping the table as it is
+		ctx := m.newScanContext()
+		m.revalidating = len(m.repos) > 0
+		return m, scanReposCmd(ctx, m.scanID, m.cfg, false)
+
+	case shellClosedMsg:
+		if msg.err != nil {
+			m.statusMsg = "❌ Shell: " + msg.err.Error()
+		} else {
+			m.statusMsg = "Back from " + msg.repo.Name
+		}
+		// Pick up whatever was done in the shell
+		return m, refreshRepoCmd(msg.repo, m.cfg)
+
+	case terminalOpenedMsg:
+		if msg.err != nil {
+			m.statusMsg = "❌ " + msg.err.Error()
+		} else {
+			m.statusMsg = "Opened a terminal in " + msg.repo.Name
+		}
+		return m, nil
+
+	case pathsCopiedMsg:
+		if msg.err != nil {
+			m.statusMsg = "❌ Clipboard unavailable: " + msg.err.Error()
+			return m, nil
+		}
+		m.statusMsg = fmt.Sprintf("📋 Copied %d paths", msg.count)
+		return m, nil
 
 	case grassDataLoadedMsg:
 		m.grassData = msg.data
@@ -121,9 +279,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case diskDataLoadedMsg:
 		m.diskData = msg.data
-		if msg.data != nil {
+		if msg.data != nil && m.activePanel == PanelDisk {
 			m.statusMsg = fmt.Sprintf("💾 %s total across %d repos", stats.FormatBytes(msg.data.TotalSize), msg.data.RepoCount)
 		}
+		if hasColumn(m.columns, "size") {
+			m.setTableRows()
+		}
 		return m, nil
 
 	case timelineDataLoadedMsg:
@@ -144,6 +305,34 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.handleWorkspaceSwitchMode(msg)
 		}
 
+		// Handle bulk actions and their results
+		switch m.state {
+		case StateBulkMenu:
+			return m.handleBulkMenuMode(msg)
+		case StateBulkCommand:
+			return m.handleBulkCommandMode(msg)
+		case StateResults:
+			return m.handleResultsMode(msg)
+		case StateDiff:
+			return m.handleDiffMode(msg)
+		case StateCommit:
+			return m.handleCommitMode(msg)
+		case StateStash:
+			return m.handleStashMode(msg)
+		case StateConfirmPush:
+			return m.handleConfirmPushMode(msg)
+		case StateViewPicker:
+			return m.handleViewPickerMode(msg)
+		}
+
+		// alt+1-9 switch views directly, alt+0 back to the default
+		if n, ok := viewKey(msg, true); ok && m.state == StateReady {
+			if !m.switchView(n) {
+				m.statusMsg = fmt.Sprintf("No view %d", n)
+			}
+			return m, m.loadSizesCmd()
+		}
+
 		// Normal mode key handling
 		switch msg.String() {
 		case "ctrl+c", "q":
@@ -170,24 +359,58 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		case "enter":
 			if m.state == StateReady {
+				if g, ok := m.selectedGroup(); ok {
+					m.toggleGroup(g)
+					return m, nil
+				}
 				repo := m.GetSelectedRepo()
 				if repo != nil {
 					m.statusMsg = "Opening " + repo.Name + " in " + m.cfg.Editor + "..."
 					return m, func() tea.Msg {
-						return openEditorMsg{path: repo.Path}
+						return openEditorMsg{paths: []string{repo.Path}}
 					}
 				}
 			}
 
+		case "o":
+			// Suspend the dashboard and open a shell in the repo
+			if m.state == StateReady {
+				if repo := m.GetSelectedRepo(); repo != nil {
+					return m, openShellCmd(*repo)
+				}
+			}
+
+		case "O":
+			// Open the configured terminal in the repo
+			if m.state == StateReady {
+				repo := m.GetSelectedRepo()
+				if repo == nil {
+					return m, nil
+				}
+				if m.cfg.Terminal == "" {
+					m.statusMsg = "No terminal configured. Set terminal in ~/.config/git-scope/config.yml, e.g. \"tmux new-window -c {path}\""
+					return m, nil
+				}
+				return m, openTerminalCmd(m.cfg.Terminal, *repo)
+			}
+
 		case "r":
-			m.state = StateLoading
+			// Pressing r again while a scan runs restarts it
+			ctx := m.newScanContext()
 			m.statusMsg = "Rescanning..."
-			return m, scanReposCmd(m.cfg)
+			if m.state == StateReady && len(m.repos) > 0 {
+				// Keep the current rows and merge fresh results in
+				m.revalidating = true
+				return m, scanReposCmd(ctx, m.scanID, m.cfg, false)
+			}
+			m.repos = nil
+			m.state = StateLoading
+			return m, tea.Batch(m.spinner.Tick, scanReposCmd(ctx, m.scanID, m.cfg, false))
 
 		case "f":
 			// Cycle through filter modes
 			if m.state == StateReady {
-				m.filterMode = (m.filterMode + 1) % 3
+				m.filterMode = (m.filterMode + 1) % filterModeCount
 				m.resetPage()
 				m.updateTable()
 				m.statusMsg = "Filter: " + m.GetFilterModeName()
@@ -203,6 +426,17 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 
+		case "G":
+			// Cycle through ways of grouping repos
+			if m.state == StateReady {
+				m.cycleGroup()
+				m.statusMsg = "Grouped by: " + groupName(m.groupBy)
+				if !m.grouped() {
+					m.statusMsg = "Grouping off"
+				}
+				return m, m.syncDetail()
+			}
+
 		case "1":
 			if m.state == StateReady {
 				m.sortMode = SortByDirty
@@ -239,10 +473,16 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 
+		case "v":
+			if m.state == StateReady {
+				m.openViewPicker()
+				return m, nil
+			}
+
 		case "c":
 			// Clear search and filters
 			if m.state == StateReady {
-				m.searchQuery = ""
+				m.setSearchQuery("")
 				m.textInput.SetValue("") // Also reset the text input
 				m.filterMode = FilterAll
 				m.resetPage()
@@ -308,6 +548,24 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 
+		case "i":
+			// Toggle the detail panel for the highlighted repo
+			if m.state == StateReady {
+				if m.activePanel == PanelDetail {
+					m.activePanel = PanelNone
+					return m, nil
+				}
+				m.activePanel = PanelDetail
+				m.detailPath = ""
+				return m, m.syncDetail()
+			}
+
+		case "D":
+			// Show the diffs of the highlighted repo's changed files
+			if m.state == StateReady {
+				return m, m.openDiff()
+			}
+
 		case "esc":
 			// Close panel if open
 			if m.activePanel != PanelNone {
@@ -315,6 +573,99 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.statusMsg = ""
 				return m, nil
 			}
+			// Otherwise clear marks
+			if len(m.selected) > 0 {
+				m.selected = make(map[string]bool)
+				m.updateTable()
+				m.statusMsg = "Selection cleared"
+				return m, nil
+			}
+
+		case " ":
+			// Mark or unmark the repo under the cursor, or the repos of
+			// the group
+			if m.state == StateReady {
+				if g, ok := m.selectedGroup(); ok {
+					m.toggleMarks(g.Repos)
+					return m, nil
+				}
+				m.toggleSelected()
+				return m, nil
+			}
+
+		case "a":
+			// Mark all repos in the current view
+			if m.state == StateReady {
+				m.toggleSelectAll()
+				return m, nil
+			}
+
+		case "b":
+			// Open the bulk-action menu for the marked repos
+			if m.state == StateReady && len(m.bulkTargets()) > 0 {
+				m.state = StateBulkMenu
+				m.bulkCursor = 0
+				return m, nil
+			}
+
+		case "R":
+			// Show the results of the last bulk operation
+			if m.state == StateReady && m.lastOp != nil {
+				m.openResults()
+				return m, nil
+			}
+
+		case "F":
+			// Fetch the repos in the current view
+			if m.state == StateReady {
+				if m.opName != "" {
+					m.statusMsg = fmt.Sprintf("⏳ Wait for the running %s to finish", m.opName)
+					return m, nil
+				}
+				if len(m.sortedRepos) == 0 {
+					return m, nil
+				}
+				m.statusMsg = ""
+				return m, m.startOp("fetch", m.sortedRepos, gitops.FetchOp(scan.OptionsFromConfig(m.cfg)))
+			}
+
+		case "P":
+			// Fast-forward the repos in the current view that are
+			// clean and behind; the rest are skipped
+			if m.state == StateReady {
+				if m.opName != "" {
+					m.statusMsg = fmt.Sprintf("⏳ Wait for the running %s to finish", m.opName)
+					return m, nil
+				}
+				if len(m.sortedRepos) == 0 {
+					return m, nil
+				}
+				m.statusMsg = ""
+				return m, m.startOp("pull", m.sortedRepos, gitops.PullOp(scan.OptionsFromConfig(m.cfg)))
+			}
+
+		case "U":
+			// Push the marked repos, or the repo under the cursor,
+			// after confirmation
+			if m.state == StateReady {
+				if targets := m.bulkTargets(); len(targets) > 0 {
+					m.confirmPush(targets)
+				}
+				return m, nil
+			}
+
+		case "W":
+			// Toggle watch mode
+			if m.state == StateReady {
+				m.watchEnabled = !m.watchEnabled
+				if !m.watchEnabled {
+					m.stopWatching()
+					m.statusMsg = "Watch mode off"
+					return m, nil
+				}
+				m.statusMsg = "👁 Watching repos for changes..."
+				return m, startWatchCmd(m.repos, m.cfg)
+			}
 
 		case "w":
 			// Open workspace switch modal
@@ -327,7 +678,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 		case "[":
-			// Previous page
+			// Previous group header when grouping, else previous page
+			if m.state == StateReady && m.grouped() {
+				m.jumpGroup(-1)
+				return m, m.syncDetail()
+			}
 			if m.state == StateReady && m.canGoPrev() {
 				m.currentPage--
 				m.updateTable()
@@ -336,7 +691,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 		case "]":
-			// Next page
+			// Next group header when grouping, else next page
+			if m.state == StateReady && m.grouped() {
+				m.jumpGroup(1)
+				return m, m.syncDetail()
+			}
 			if m.state == StateReady && m.canGoNext() {
 				m.currentPage++
 				m.updateTable()
@@ -354,23 +713,80 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	// Update the table
 	m.table, cmd = m.table.Update(msg)
+	m.scrollTable()
 	cmds = append(cmds, cmd)
+
+	// Follow the cursor with the detail panel
+	cmds = append(cmds, m.syncDetail())
 	return m, tea.Batch(cmds...)
 }
 
+// scanFinished updates the status line once a streaming scan completes
+// and returns the follow-up command: caching the results of a scan of the
+// configured roots, or nothing for a workspace switch
+func (m *Model) scanFinished(refreshed bool) tea.Cmd {
+	if m.scanWorkspace != "" {
+		// Show helpful message about switched workspace
+		if len(m.repos) == 0 {
+			m.statusMsg = fmt.Sprintf("⚠️  No git repos found in %s", m.scanWorkspace)
+			return nil
+		}
+		m.statusMsg = fmt.Sprintf("✓ Switched to %s (%d repos)", m.scanWorkspace, len(m.repos))
+		m.diskData = nil
+
+		// Trigger star nudge after successful workspace switch
+		if nudge.ShouldShowNudge() && !m.nudgeShownThisSession {
+			m.showStarNudge = true
+			m.nudgeShownThisSession = true
+			nudge.MarkShown()
+		}
+		return m.loadSizesCmd()
+	}
+
+	// Show helpful message if no repos found
+	if len(m.repos) == 0 {
+		m.statusMsg = "⚠️  No git repos found in configured directories. Press 'r' to rescan or run 'git-scope init' to configure."
+		return nil
+	}
+	m.statusMsg = fmt.Sprintf("✓ Found %d repos", len(m.repos))
+	if refreshed {
+		m.statusMsg = fmt.Sprintf("✓ Refreshed %d repos", len(m.repos))
+	}
+
+	repos := make([]model.Repo, len(m.repos))
+	copy(repos, m.repos)
+	cmd := saveCacheCmd(repos, m.cfg.Roots)
+	if hasColumn(m.columns, "size") {
+		// Sizes of the last scan may be out of date
+		cmd = tea.Batch(cmd, loadDiskDataCmd(repos))
+	}
+	return cmd
+}
+
 // handleSearchMode handles key events when in search mode
 func (m Model) handleSearchMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "esc":
-		// Cancel search, keep previous query
+		// Cancel search, keep previous query; a query that does not
+		// parse falls back to the last one that did
+		if m.queryErr != nil {
+			if m.query != nil {
+				m.setSearchQuery(m.query.String())
+			} else {
+				m.setSearchQuery("")
+			}
+		}
 		m.state = StateReady
 		m.resizeTable()
 		m.textInput.Blur()
 		return m, nil
 
 	case "enter":
-		// Apply search
-		m.searchQuery = m.textInput.Value()
+		// Apply search, unless the query does not parse
+		m.setSearchQuery(m.textInput.Value())
+		if m.queryErr != nil {
+			return m, nil
+		}
 		m.state = StateReady
 		m.resizeTable()
 		m.textInput.Blur()
@@ -391,9 +807,18 @@ func (m Model) handleSearchMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 	m.textInput, cmd = m.textInput.Update(msg)
 
-	// Live search as you type
-	m.searchQuery = m.textInput.Value()
+	// Live search as you type, from the best match
+	changed := m.textInput.Value() != m.searchQuery
+	m.setSearchQuery(m.textInput.Value())
+	m.resizeTable()
+	if changed {
+		m.resetPage()
+	}
 	m.updateTable()
+	if changed {
+		m.table.SetCursor(0)
+		m.scrollTable()
+	}
 
 	return m, cmd
 }
@@ -403,6 +828,27 @@ type editorClosedMsg struct {
 	err error
 }
 
+// detailLoadedMsg is sent when the details of a repo are loaded
+type detailLoadedMsg struct {
+	path string
+	data *model.RepoDetails
+	err  error
+}
+
+// loadDetailCmd loads the changed files, branches, commits and stashes of
+// a repo
+func loadDetailCmd(path string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), scan.DefaultTimeout)
+		defer cancel()
+		data, err := gitstatus.Details(ctx, path, gitstatus.DefaultCommitCount)
+		if err != nil {
+			return detailLoadedMsg{path: path, err: err}
+		}
+		return detailLoadedMsg{path: path, data: &data}
+	}
+}
+
 // grassDataLoadedMsg is sent when contribution data is loaded
 type grassDataLoadedMsg struct {
 	data *stats.ContributionData
@@ -468,13 +914,16 @@ func (m Model) handleWorkspaceSwitchMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 
 		// Switch to loading state and scan the new workspace
+		ctx := m.newScanContext()
+		m.scanWorkspace = normalizedPath
+		m.repos = nil
 		m.state = StateLoading
 		m.workspaceInput.Blur()
 		m.workspaceError = ""
 		m.activeWorkspace = normalizedPath
 		m.statusMsg = "🔄 Switching to " + normalizedPath + "..."
 
-		return m, scanWorkspaceCmd(normalizedPath, m.cfg.Ignore)
+		return m, tea.Batch(m.spinner.Tick, scanWorkspaceCmd(ctx, m.scanID, normalizedPath, m.cfg))
 
 	case "tab":
 		// Tab completion for directory paths
@@ -505,32 +954,6 @@ func (m Model) handleWorkspaceSwitchMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
-// workspaceScanCompleteMsg is sent when workspace scanning is complete
-type workspaceScanCompleteMsg struct {
-	repos         []model.Repo
-	workspacePath string
-}
-
-// workspaceScanErrorMsg is sent when workspace scanning fails
-type workspaceScanErrorMsg struct {
-	err error
-}
-
-// scanWorkspaceCmd scans a single workspace path for repositories
-func scanWorkspaceCmd(workspacePath string, ignore []string) tea.Cmd {
-	return func() tea.Msg {
-		repos, err := scan.ScanRoots([]string{workspacePath}, ignore)
-		if err != nil {
-			return workspaceScanErrorMsg{err: err}
-		}
-
-		return workspaceScanCompleteMsg{
-			repos:         repos,
-			workspacePath: workspacePath,
-		}
-	}
-}
-
 // openBrowserCmd opens a URL in the default browser
 func openBrowserCmd(url string) tea.Cmd {
 	return func() tea.Msg {
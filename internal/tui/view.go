@@ -1,9 +1,12 @@
 package tui
 
 import (
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/Bharath-code/git-scope/internal/gitops"
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -25,6 +28,18 @@ func (m Model) renderContent() string {
 		b.WriteString(m.renderDashboard())
 	case StateWorkspaceSwitch:
 		b.WriteString(m.renderWorkspaceModal())
+	case StateBulkMenu:
+		b.WriteString(m.renderBulkMenu())
+	case StateBulkCommand:
+		b.WriteString(m.renderBulkCommand())
+	case StateResults:
+		b.WriteString(m.renderResults())
+	case StateDiff, StateCommit, StateStash:
+		b.WriteString(m.renderDiff())
+	case StateConfirmPush:
+		b.WriteString(m.renderConfirmPush())
+	case StateViewPicker:
+		b.WriteString(m.renderViewPicker())
 	}
 
 	return b.String()
@@ -38,6 +53,10 @@ func (m Model) renderLoading() string {
 	b.WriteString(m.spinner.View())
 	b.WriteString(" ")
 	b.WriteString(loadingStyle.Render("Scanning repositories..."))
+	if m.scanning {
+		b.WriteString(" ")
+		b.WriteString(subtitleStyle.Render(m.scanProgress()))
+	}
 	b.WriteString("\n\n")
 
 	b.WriteString(subtitleStyle.Render("Searching for git repos in:"))
@@ -127,7 +146,8 @@ func (m Model) renderDashboard() string {
 	// Main content area - split pane if panel is active
 	if m.activePanel != PanelNone {
 		// Render table content
-		tableContent := m.table.View()
+		tableWidth, _ := splitPaneWidths(m.width - 4)
+		tableContent := m.renderTable(tableWidth)
 
 		// Render panel content based on active panel
 		var panelContent string
@@ -138,12 +158,14 @@ func (m Model) renderDashboard() string {
 			panelContent = renderDiskPanel(m.diskData, m.width/2, m.height-15)
 		case PanelTimeline:
 			panelContent = renderTimelinePanel(m.timelineData, m.width/2, m.height-15)
+		case PanelDetail:
+			panelContent = m.renderDetail(m.width/2, m.height-15)
 		}
 
 		b.WriteString(renderSplitPane(tableContent, panelContent, m.width-4))
 	} else {
 		// Full-width table
-		b.WriteString(m.table.View())
+		b.WriteString(m.renderTable(m.width - 4))
 	}
 	b.WriteString("\n")
 
@@ -169,6 +191,16 @@ func (m Model) renderDashboard() string {
 	return b.String()
 }
 
+// renderDetail renders the detail panel, showing loaded details only if
+// they belong to the highlighted repo
+func (m Model) renderDetail(width, height int) string {
+	repo := m.GetSelectedRepo()
+	if repo == nil || repo.Path != m.detailPath {
+		return renderDetailPanel(repo, nil, nil, width, height)
+	}
+	return renderDetailPanel(repo, m.detailData, m.detailErr, width, height)
+}
+
 func (m Model) renderSearchBar() string {
 	searchStyle := lipgloss.NewStyle().
 		BorderStyle(lipgloss.RoundedBorder()).
@@ -180,7 +212,30 @@ func (m Model) renderSearchBar() string {
 		Foreground(lipgloss.Color("#7C3AED")).
 		Bold(true).
 		Render("🔍 Search: ")
-	return searchStyle.Render(label + m.textInput.View())
+	bar := searchStyle.Render(label + m.textInput.View())
+	if m.queryErr == nil {
+		return bar
+	}
+	return bar + "\n" + m.renderQueryError()
+}
+
+// renderQueryError shows the search query with the term that does not
+// parse highlighted, followed by the reason
+func (m Model) renderQueryError() string {
+	q, e := m.searchQuery, m.queryErr
+	if e.Start < 0 || e.End > len(q) || e.Start > e.End {
+		return queryErrorStyle.Render("✗ " + e.Msg)
+	}
+	bad := q[e.Start:e.End]
+	if bad == "" {
+		bad = " "
+	}
+	msg := "  ✗ " + e.Msg
+	if room := m.width - 8 - len([]rune(q)); room > 10 {
+		msg = truncateString(msg, room)
+	}
+	return "  " + hintStyle.Render(q[:e.Start]) + queryErrorTermStyle.Render(bad) + hintStyle.Render(q[e.End:]) +
+		queryErrorStyle.Render(msg)
 }
 
 func (m Model) renderSearchBadge() string {
@@ -208,12 +263,27 @@ func (m Model) renderStats() string {
 	shown := len(m.sortedRepos)
 	dirty := 0
 	clean := 0
+	stashed := 0
+	inProgress := 0
+	unpushed := 0
 	for _, r := range m.repos {
+		if m.pending[r.Path] {
+			continue
+		}
 		if r.Status.IsDirty {
 			dirty++
 		} else {
 			clean++
 		}
+		if r.Status.Stashes > 0 {
+			stashed++
+		}
+		if r.Status.IsInProgress() {
+			inProgress++
+		}
+		if r.Status.Ahead > 0 {
+			unpushed++
+		}
 	}
 
 	stats := []string{}
@@ -231,6 +301,47 @@ func (m Model) renderStats() string {
 	if clean > 0 {
 		stats = append(stats, cleanBadgeStyle.Render(fmt.Sprintf("✓ %d clean", clean)))
 	}
+	if inProgress > 0 {
+		stats = append(stats, inProgressBadgeStyle.Render(fmt.Sprintf("⚠ %d in progress", inProgress)))
+	}
+	if stashed > 0 {
+		stats = append(stats, stashBadgeStyle.Render(fmt.Sprintf("⚑ %d stashed", stashed)))
+	}
+	if unpushed > 0 {
+		stats = append(stats, unpushedBadgeStyle.Render(fmt.Sprintf("⇡ %d unpushed", unpushed)))
+	}
+
+	// Age of cached rows until the background refresh replaces them
+	if !m.cachedAt.IsZero() {
+		stats = append(stats, cacheBadgeStyle.Render("🕒 cached "+formatAge(time.Since(m.cachedAt))))
+	}
+
+	if m.watcher != nil {
+		stats = append(stats, watchBadgeStyle.Render("👁 live"))
+	}
+
+	if n := len(m.selectedRepos()); n > 0 {
+		stats = append(stats, selectBadgeStyle.Render(fmt.Sprintf("◆ %d selected", n)))
+	}
+
+	// Progress of a bulk operation
+	if m.opName != "" {
+		stats = append(stats, opBadgeStyle.Render(fmt.Sprintf("⇣ %s %d/%d", m.opName, len(m.opResults), m.opTotal)))
+	}
+
+	// Live progress while a streaming scan is still running
+	if m.scanning {
+		stats = append(stats, scanBadgeStyle.Render("⟳ "+m.scanProgress()))
+	}
+
+	// Active view, marked when its settings were changed since
+	if m.activeView != "" {
+		label := "▣ " + m.activeView
+		if m.viewChanged() {
+			label += "*"
+		}
+		stats = append(stats, viewBadgeStyle.Render(label)+hintStyle.Render(" ("+m.viewsKey()+")"))
+	}
 
 	// Filter indicator with inline hint
 	if m.filterMode != FilterAll {
@@ -253,6 +364,12 @@ func (m Model) renderStats() string {
 	sortHint := hintStyle.Render(" (s)")
 	stats = append(stats, sortBadge+sortHint)
 
+	// Grouping indicator with the number of groups
+	if m.grouped() {
+		groupBadge := groupBadgeStyle.Render(fmt.Sprintf("⊞ %s · %d", groupName(m.groupBy), len(m.groups)))
+		stats = append(stats, groupBadge+hintStyle.Render(" (G)"))
+	}
+
 	// Pagination indicator (only show if more than one page)
 	totalPages := m.getTotalPages()
 	if totalPages > 1 {
@@ -268,13 +385,83 @@ func (m Model) renderStats() string {
 	return lipgloss.JoinHorizontal(lipgloss.Center, stats...)
 }
 
+// scanProgress returns the "found N / status M" counter of a running scan
+func (m Model) scanProgress() string {
+	return fmt.Sprintf("found %d / status %d", m.scanFound, m.scanQueried)
+}
+
+// opSummary describes the outcome of a bulk operation: how many repos it
+// skipped and why, and which repos failed and why
+func opSummary(name string, total int, results []gitops.Result) string {
+	name = strings.ToUpper(name[:1]) + name[1:]
+
+	var failed []gitops.Result
+	skipped := make(map[string]int)
+	var skipReasons []string
+	for _, res := range results {
+		if res.Err == nil {
+			continue
+		}
+		var skip *gitops.SkipError
+		if errors.As(res.Err, &skip) {
+			if skipped[skip.Reason] == 0 {
+				skipReasons = append(skipReasons, skip.Reason)
+			}
+			skipped[skip.Reason]++
+			continue
+		}
+		failed = append(failed, res)
+	}
+
+	done := len(results) - len(failed)
+	for _, n := range skipped {
+		done -= n
+	}
+	summary := fmt.Sprintf("%s done for %d of %d repos", name, done, total)
+	if len(skipReasons) > 0 {
+		counts := make([]string, len(skipReasons))
+		for i, reason := range skipReasons {
+			counts[i] = fmt.Sprintf("%d %s", skipped[reason], reason)
+		}
+		summary += " · skipped " + strings.Join(counts, ", ")
+	}
+	if len(failed) == 0 {
+		return "✓ " + summary
+	}
+
+	const maxListed = 2
+	reasons := make([]string, 0, maxListed)
+	for i, res := range failed {
+		if i == maxListed {
+			reasons = append(reasons, fmt.Sprintf("+%d more", len(failed)-maxListed))
+			break
+		}
+		reasons = append(reasons, fmt.Sprintf("%s: %v", res.Repo.Name, res.Err))
+	}
+	return fmt.Sprintf("❌ %s · %d failed — %s", summary, len(failed), strings.Join(reasons, "; "))
+}
+
+// formatAge formats a duration as a compact age like "3m ago"
+func formatAge(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	}
+	return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+}
+
 // renderLegend renders a compact single-line legend (Tuimorphic style)
 func (m Model) renderLegend() string {
 	dirty := dirtyDotStyle.Render("●") + legendStyle.Render(" dirty")
 	clean := cleanDotStyle.Render("○") + legendStyle.Render(" clean")
+	tracking := legendStyle.Render("↑↓ ahead/behind  ⊘ no upstream  ✗ upstream gone  ➦ detached")
 	editor := legendStyle.Render(fmt.Sprintf("  Editor: %s", m.cfg.Editor))
 
-	return legendStyle.Render(dirty + "  " + clean + editor)
+	return legendStyle.Render(dirty + "  " + clean + "  " + tracking + editor)
 }
 
 // renderHelp renders a Tuimorphic keybindings bar with box-drawing separators
@@ -297,11 +484,66 @@ func (m Model) renderHelp() string {
 			keyBinding("enter", "switch"),
 			keyBinding("esc", "cancel"),
 		}
+	} else if m.state == StateBulkMenu {
+		items = []string{
+			keyBinding("↑↓", "choose"),
+			keyBinding("enter", "run"),
+			keyBinding("esc", "cancel"),
+		}
+	} else if m.state == StateBulkCommand {
+		items = []string{
+			keyBinding("type", "command"),
+			keyBinding("enter", "run"),
+			keyBinding("esc", "cancel"),
+		}
+	} else if m.state == StateResults {
+		items = []string{
+			keyBinding("↑↓", "scroll"),
+			keyBinding("esc", "close"),
+		}
+	} else if m.state == StateDiff {
+		items = []string{
+			keyBinding("↑↓", "file"),
+			keyBinding("j/k", "scroll"),
+			keyBinding("s", "staged/worktree"),
+			keyBinding("space", "stage/unstage"),
+			keyBinding("a/u", "stage/unstage all"),
+			keyBinding("c", "commit"),
+			keyBinding("z/Z", "stash/pop"),
+			keyBinding("e", "edit"),
+			keyBinding("esc", "back"),
+		}
+	} else if m.state == StateViewPicker {
+		items = []string{
+			keyBinding("↑↓", "choose"),
+			keyBinding("1-9", "switch"),
+			keyBinding("0", "default"),
+			keyBinding("esc", "cancel"),
+		}
+	} else if m.state == StateConfirmPush {
+		items = []string{
+			keyBinding("y", "push"),
+			keyBinding("n", "cancel"),
+		}
+	} else if m.state == StateCommit {
+		items = []string{
+			keyBinding("type", "message"),
+			keyBinding("ctrl+s", "commit"),
+			keyBinding("esc", "cancel"),
+		}
+	} else if m.state == StateStash {
+		items = []string{
+			keyBinding("type", "message"),
+			keyBinding("enter", "stash"),
+			keyBinding("esc", "cancel"),
+		}
 	} else if m.activePanel != PanelNone {
 		// Panel active help
 		items = []string{
 			keyBinding("↑↓", "nav"),
 			keyBinding("esc", "close"),
+			keyBinding("i", "detail"),
+			keyBinding("D", "diff"),
 			keyBinding("g", "grass"),
 			keyBinding("d", "disk"),
 			keyBinding("t", "time"),
@@ -309,18 +551,33 @@ func (m Model) renderHelp() string {
 		}
 	} else {
 		// Normal mode help - Tuimorphic style
+		page, open := "page", "open"
+		if m.grouped() {
+			page, open = "group", "open/fold"
+		}
 		items = []string{
 			keyBinding("↑↓", "nav"),
-			keyBinding("[]", "page"),
-			keyBinding("enter", "open"),
+			keyBinding("[]", page),
+			keyBinding("enter", open),
+			keyBinding("o", "shell"),
+			keyBinding("space", "mark"),
+			keyBinding("b", "bulk"),
 			keyBinding("/", "search"),
+			keyBinding("i", "detail"),
+			keyBinding("D", "diff"),
 			keyBinding("w", "workspace"),
 			keyBinding("f", "filter"),
 			keyBinding("s", "sort"),
+			keyBinding("G", "group"),
+			keyBinding(m.viewsKey(), "views"),
 			keyBinding("g", "grass"),
 			keyBinding("d", "disk"),
 			keyBinding("t", "time"),
 			keyBinding("r", "rescan"),
+			keyBinding("F", "fetch"),
+			keyBinding("P", "pull"),
+			keyBinding("U", "push"),
+			keyBinding("W", "watch"),
 			keyBinding("q", "quit"),
 		}
 	}
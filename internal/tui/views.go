@@ -0,0 +1,377 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Bharath-code/git-scope/internal/config"
+	"github.com/Bharath-code/git-scope/internal/filter"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxViewKeys is how many views can be picked with a number key
+const maxViewKeys = 9
+
+// viewPreset is a view of the config resolved to dashboard settings
+type viewPreset struct {
+	name    string
+	query   string
+	filter  FilterMode
+	sort    SortMode
+	columns []repoColumn
+	group   filter.GroupKey
+}
+
+// filterModeNames maps the filter names of the config to filter modes
+var filterModeNames = map[string]FilterMode{
+	"all":         FilterAll,
+	"dirty":       FilterDirty,
+	"clean":       FilterClean,
+	"stashed":     FilterStashed,
+	"in-progress": FilterInProgress,
+	"no-upstream": FilterNoUpstream,
+	"unpushed":    FilterUnpushed,
+}
+
+// parseViews resolves the default view and the views of the config,
+// failing on the first setting that is invalid. The default view shows
+// all repos in the configured columns and grouping.
+func parseViews(cfg *config.Config) (viewPreset, []viewPreset, error) {
+	fallback, _ := parseColumns(defaultColumns, nil)
+	base := viewPreset{filter: FilterAll, sort: SortByDirty, columns: fallback}
+	cols, err := parseColumns(cfg.Columns, fallback)
+	if err != nil {
+		return base, nil, fmt.Errorf("columns: %w", err)
+	}
+	base.columns = cols
+	if cfg.Group != "" {
+		if base.group, err = parseGroup(cfg.Group, cfg); err != nil {
+			return base, nil, err
+		}
+	}
+
+	presets := make([]viewPreset, 0, len(cfg.Views))
+	seen := make(map[string]bool, len(cfg.Views))
+	for i, v := range cfg.Views {
+		if v.Name == "" {
+			return base, nil, fmt.Errorf("view %d has no name", i+1)
+		}
+		if seen[v.Name] {
+			return base, nil, fmt.Errorf("view %q is defined twice", v.Name)
+		}
+		seen[v.Name] = true
+
+		p, err := parseView(v, base, cfg)
+		if err != nil {
+			return base, nil, fmt.Errorf("view %q: %w", v.Name, err)
+		}
+		presets = append(presets, p)
+	}
+	return base, presets, nil
+}
+
+// parseView resolves one view of the config. Settings it leaves out are
+// taken from base.
+func parseView(v config.View, base viewPreset, cfg *config.Config) (viewPreset, error) {
+	p := base
+	p.name = v.Name
+	p.query = strings.TrimSpace(v.Query)
+
+	if _, err := filter.ParseQuery(p.query); err != nil {
+		return p, fmt.Errorf("query: %w", err)
+	}
+	if v.Filter != "" {
+		mode, ok := filterModeNames[v.Filter]
+		if !ok {
+			return p, fmt.Errorf("unknown filter %q (want dirty, clean, stashed, in-progress, no-upstream or unpushed)", v.Filter)
+		}
+		p.filter = mode
+	}
+	if v.Sort != "" {
+		key, err := filter.ParseSortKey(v.Sort)
+		if err != nil {
+			return p, err
+		}
+		p.sort = sortModeFor(key)
+	}
+	cols, err := parseColumns(v.Columns, base.columns)
+	if err != nil {
+		return p, err
+	}
+	p.columns = cols
+	if v.Group != "" {
+		if p.group, err = parseGroup(v.Group, cfg); err != nil {
+			return p, err
+		}
+	}
+	return p, nil
+}
+
+// sortModeFor returns the sort mode of a shared sort key
+func sortModeFor(key filter.SortKey) SortMode {
+	switch key {
+	case filter.SortName:
+		return SortByName
+	case filter.SortBranch:
+		return SortByBranch
+	case filter.SortRecent:
+		return SortByLastCommit
+	}
+	return SortByDirty
+}
+
+// startView applies the view named in the config, if any
+func (m *Model) startView(name string) error {
+	if m.configErr != nil {
+		return m.configErr
+	}
+	if name == "" {
+		return nil
+	}
+	for _, v := range m.views {
+		if v.name == name {
+			m.applyView(v)
+			return nil
+		}
+	}
+	if len(m.views) == 0 {
+		return fmt.Errorf("unknown view %q: no views are configured", name)
+	}
+	return fmt.Errorf("unknown view %q (have %s)", name, strings.Join(m.viewNames(), ", "))
+}
+
+// viewNames lists the names of the configured views
+func (m Model) viewNames() []string {
+	names := make([]string, len(m.views))
+	for i, v := range m.views {
+		names[i] = v.name
+	}
+	return names
+}
+
+// applyView switches the dashboard to the filter, sort, query, columns
+// and grouping of a view. The default view resets them.
+func (m *Model) applyView(v viewPreset) {
+	m.activeView = v.name
+	m.filterMode = v.filter
+	m.sortMode = v.sort
+	m.groupBy = v.group
+	m.setSearchQuery(v.query)
+	m.textInput.SetValue(v.query)
+	m.setColumns(v.columns)
+	m.resetPage()
+	m.resizeTable()
+	m.updateTable()
+	m.table.SetCursor(0)
+	m.scrollTable()
+}
+
+// setColumns changes the columns of the table
+func (m *Model) setColumns(cols []repoColumn) {
+	// The table renders its rows on every change, so drop the rows
+	// before their cells stop matching the columns
+	m.table.SetRows(nil)
+	m.columns = cols
+	m.table.SetColumns(tableColumns(cols))
+}
+
+// currentView returns the active view, or the default view
+func (m Model) currentView() viewPreset {
+	for _, v := range m.views {
+		if v.name == m.activeView {
+			return v
+		}
+	}
+	return m.defaultView
+}
+
+// viewChanged reports whether the filter, sort, query, columns or
+// grouping were changed since the active view was applied
+func (m Model) viewChanged() bool {
+	v := m.currentView()
+	if m.filterMode != v.filter || m.sortMode != v.sort || m.searchQuery != v.query || m.groupBy != v.group {
+		return true
+	}
+	return !sameColumns(m.columns, v.columns)
+}
+
+// sameColumns reports whether a and b are the same columns in the same
+// order
+func sameColumns(a, b []repoColumn) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].key != b[i].key {
+			return false
+		}
+	}
+	return true
+}
+
+// openViewPicker shows the configured views to switch to
+func (m *Model) openViewPicker() {
+	if len(m.views) == 0 {
+		m.statusMsg = "No views configured. Add views to ~/.config/git-scope/config.yml"
+		return
+	}
+	m.viewCursor = 0
+	for i, v := range m.views {
+		if v.name == m.activeView {
+			m.viewCursor = i
+		}
+	}
+	m.state = StateViewPicker
+}
+
+// viewsKey describes the keys that switch views: v opens the picker, in
+// which number keys pick a view, e.g. "v 1-3"
+func (m Model) viewsKey() string {
+	n := len(m.views)
+	if n > maxViewKeys {
+		n = maxViewKeys
+	}
+	switch n {
+	case 0:
+		return "v"
+	case 1:
+		return "v 1"
+	}
+	return fmt.Sprintf("v 1-%d", n)
+}
+
+// switchView applies the view picked with number key n, where 0 is the
+// default view, reporting false when there is no such view
+func (m *Model) switchView(n int) bool {
+	if n == 0 {
+		m.applyView(m.defaultView)
+		m.statusMsg = "View: default"
+		return true
+	}
+	if n > len(m.views) {
+		return false
+	}
+	v := m.views[n-1]
+	m.applyView(v)
+	m.statusMsg = "View: " + v.name
+	return true
+}
+
+// viewKey returns the view number of a number key, with or without alt
+func viewKey(msg tea.KeyMsg, alt bool) (int, bool) {
+	if len(msg.Runes) != 1 || msg.Alt != alt {
+		return 0, false
+	}
+	n, err := strconv.Atoi(string(msg.Runes))
+	if err != nil || n > maxViewKeys {
+		return 0, false
+	}
+	return n, true
+}
+
+// handleViewPickerMode handles keys in the view picker
+func (m Model) handleViewPickerMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "v", "q":
+		m.state = StateReady
+		return m, nil
+	case "ctrl+c":
+		return m, tea.Quit
+	case "up", "k":
+		if m.viewCursor > 0 {
+			m.viewCursor--
+		}
+		return m, nil
+	case "down", "j":
+		if m.viewCursor < len(m.views)-1 {
+			m.viewCursor++
+		}
+		return m, nil
+	case "enter":
+		m.state = StateReady
+		m.switchView(m.viewCursor + 1)
+		return m, m.loadSizesCmd()
+	}
+
+	if n, ok := viewKey(msg, false); ok {
+		if m.switchView(n) {
+			m.state = StateReady
+			return m, m.loadSizesCmd()
+		}
+	}
+	return m, nil
+}
+
+// renderViewPicker renders the list of views to switch to
+func (m Model) renderViewPicker() string {
+	var b strings.Builder
+
+	b.WriteString(compactLogo())
+	b.WriteString("\n\n")
+
+	modalStyle := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#7C3AED")).
+		Padding(1, 2).
+		Width(64)
+
+	title := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#A78BFA")).
+		Bold(true).
+		Render("▣ Views")
+
+	var items strings.Builder
+	for i, v := range m.views {
+		key := " "
+		if i < maxViewKeys {
+			key = strconv.Itoa(i + 1)
+		}
+		line := keyBindingKeyStyle.Render(key) + "  " + v.name
+		if v.name == m.activeView {
+			line += " ●"
+		}
+		if desc := describeView(v, m.defaultView); desc != "" {
+			line += "  " + hintStyle.Render(truncateString(desc, 40))
+		}
+		if i == m.viewCursor {
+			line = lipgloss.NewStyle().Foreground(primaryDim).Bold(true).Render("▸ ") + line
+		} else {
+			line = "  " + line
+		}
+		items.WriteString(line + "\n")
+	}
+	items.WriteString("  " + keyBindingKeyStyle.Render("0") + "  default\n")
+
+	footer := lipgloss.NewStyle().
+		Foreground(mutedColor).
+		Render("\n↑↓ = choose   1-9 = switch   0 = default   Esc = cancel")
+
+	b.WriteString(modalStyle.Render(title + "\n\n" + items.String() + footer))
+	b.WriteString("\n\n")
+	b.WriteString(m.renderHelp())
+	return b.String()
+}
+
+// describeView summarizes how a view differs from base, e.g.
+// "ahead>0 · Unpushed · Recent"
+func describeView(v, base viewPreset) string {
+	var parts []string
+	if v.query != "" {
+		parts = append(parts, v.query)
+	}
+	if v.filter != FilterAll {
+		parts = append(parts, v.filter.name())
+	}
+	if v.sort != base.sort {
+		parts = append(parts, v.sort.name())
+	}
+	if !sameColumns(v.columns, base.columns) {
+		parts = append(parts, fmt.Sprintf("%d columns", len(v.columns)))
+	}
+	if v.group != base.group {
+		parts = append(parts, "Group: "+groupName(v.group))
+	}
+	return strings.Join(parts, " · ")
+}
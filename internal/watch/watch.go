@@ -0,0 +1,198 @@
+package watch
+
+import (
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/Bharath-code/git-scope/internal/cache"
+	"github.com/Bharath-code/git-scope/internal/model"
+)
+
+// DefaultDebounce is how long a repo must be quiet before a change to it
+// is reported
+const DefaultDebounce = 300 * time.Millisecond
+
+// pollInterval is how often repos without native notifications are checked
+const pollInterval = 2 * time.Second
+
+// Watcher reports repositories that changed on disk. It uses inotify where
+// available and falls back to polling each repo's fingerprint and working
+// tree otherwise.
+// Changes are debounced per repo, so a burst of writes (a commit, a
+// checkout, an editor saving many files) is reported once it settles.
+type Watcher struct {
+	changes  chan string
+	events   chan string
+	done     chan struct{}
+	debounce time.Duration
+
+	closeOnce sync.Once
+	closeFn   func() error
+	wg        sync.WaitGroup
+}
+
+// New starts watching the git dir and working tree of every repo. skip
+// reports working tree directories that should not be watched (e.g.
+// node_modules). A debounce of zero or less uses DefaultDebounce.
+func New(repos []model.Repo, skip func(name string) bool, debounce time.Duration) (*Watcher, error) {
+	if debounce <= 0 {
+		debounce = DefaultDebounce
+	}
+
+	w := &Watcher{
+		changes:  make(chan string, 256),
+		events:   make(chan string, 64),
+		done:     make(chan struct{}),
+		debounce: debounce,
+	}
+
+	polled, closeFn, err := startNative(w, repos, skip)
+	if err != nil {
+		// No native notifications at all: poll everything
+		polled, closeFn = repos, nil
+	}
+	w.closeFn = closeFn
+
+	w.wg.Add(1)
+	go w.debounceLoop()
+
+	if len(polled) > 0 {
+		w.wg.Add(1)
+		go w.pollLoop(polled, skip)
+	}
+
+	return w, nil
+}
+
+// Events returns the channel on which paths of changed repos are sent.
+// It is closed by Close.
+func (w *Watcher) Events() <-chan string {
+	return w.events
+}
+
+// Close stops watching and closes the Events channel
+func (w *Watcher) Close() error {
+	var err error
+	w.closeOnce.Do(func() {
+		close(w.done)
+		if w.closeFn != nil {
+			err = w.closeFn()
+		}
+		w.wg.Wait()
+		close(w.events)
+	})
+	return err
+}
+
+// notify records a raw change to the repo at path
+func (w *Watcher) notify(path string) {
+	select {
+	case w.changes <- path:
+	case <-w.done:
+	}
+}
+
+// debounceLoop reports a repo once no change to it was seen for the
+// debounce period
+func (w *Watcher) debounceLoop() {
+	defer w.wg.Done()
+
+	lastChange := make(map[string]time.Time)
+	ticker := time.NewTicker(w.debounce / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case path := <-w.changes:
+			lastChange[path] = time.Now()
+
+		case now := <-ticker.C:
+			for path, t := range lastChange {
+				if now.Sub(t) < w.debounce {
+					continue
+				}
+				delete(lastChange, path)
+				select {
+				case w.events <- path:
+				case <-w.done:
+					return
+				}
+			}
+
+		case <-w.done:
+			return
+		}
+	}
+}
+
+// pollLoop reports repos whose git dir fingerprint or working tree
+// changed since the last check
+func (w *Watcher) pollLoop(repos []model.Repo, skip func(name string) bool) {
+	defer w.wg.Done()
+
+	stamps := make(map[string]string, len(repos))
+	for _, r := range repos {
+		stamps[r.Path] = repoStamp(r, skip)
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			for _, r := range repos {
+				stamp := repoStamp(r, skip)
+				if stamp != stamps[r.Path] {
+					stamps[r.Path] = stamp
+					w.notify(r.Path)
+				}
+			}
+		case <-w.done:
+			return
+		}
+	}
+}
+
+// repoStamp summarizes a repo's git dir fingerprint together with the
+// modification times and sizes of its working tree files
+func repoStamp(r model.Repo, skip func(name string) bool) string {
+	return cache.Fingerprint(r) + ":" + treeStamp(r.Path, skip)
+}
+
+// treeStamp hashes the modification times and sizes of the files and
+// directories below root, skipping .git, ignored directories and nested
+// repos, which are polled on their own
+func treeStamp(root string, skip func(name string) bool) string {
+	h := fnv.New64a()
+	_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if path != root {
+			if d.Name() == ".git" || (d.IsDir() && skip(d.Name())) {
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if d.IsDir() {
+				if _, err := os.Lstat(filepath.Join(path, ".git")); err == nil {
+					return filepath.SkipDir
+				}
+			}
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		fmt.Fprintf(h, "%s:%d:%d;", path, info.ModTime().UnixNano(), info.Size())
+		return nil
+	})
+	return fmt.Sprintf("%016x", h.Sum64())
+}
@@ -0,0 +1,198 @@
+//go:build linux
+
+package watch
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"unsafe"
+
+	"github.com/Bharath-code/git-scope/internal/model"
+	"golang.org/x/sys/unix"
+)
+
+// inotifyMask selects the events that can change what git status reports
+const inotifyMask = unix.IN_CLOSE_WRITE | unix.IN_CREATE | unix.IN_DELETE |
+	unix.IN_MOVED_FROM | unix.IN_MOVED_TO | unix.IN_ATTRIB
+
+// watchedDir is the repo a watch descriptor belongs to
+type watchedDir struct {
+	repo     string
+	dir      string
+	worktree bool // working tree directory: new subdirectories are watched too
+}
+
+// inotifyWatcher maps inotify watch descriptors back to repos
+type inotifyWatcher struct {
+	fd   int
+	file *os.File
+	skip func(name string) bool
+
+	mu   sync.Mutex
+	dirs map[int]watchedDir
+}
+
+// startNative watches every repo with inotify. Repos that cannot be fully
+// covered (e.g. the watch limit is reached) are returned to be polled.
+func startNative(w *Watcher, repos []model.Repo, skip func(name string) bool) ([]model.Repo, func() error, error) {
+	fd, err := unix.InotifyInit1(unix.IN_CLOEXEC | unix.IN_NONBLOCK)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	iw := &inotifyWatcher{
+		fd:   fd,
+		file: os.NewFile(uintptr(fd), "inotify"),
+		skip: skip,
+		dirs: make(map[int]watchedDir),
+	}
+
+	var polled []model.Repo
+	for _, r := range repos {
+		if !iw.addRepo(r) {
+			polled = append(polled, r)
+		}
+	}
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		iw.readLoop(w)
+	}()
+
+	return polled, iw.file.Close, nil
+}
+
+// addRepo watches the git dir, the refs and the working tree of a repo.
+// It reports false if any directory could not be watched.
+func (iw *inotifyWatcher) addRepo(r model.Repo) bool {
+	ok := true
+
+	if r.GitDir != "" {
+		ok = iw.add(watchedDir{repo: r.Path, dir: r.GitDir}) && ok
+
+		commonDir := r.CommonDir
+		if commonDir == "" {
+			commonDir = r.GitDir
+		}
+		if commonDir != r.GitDir {
+			ok = iw.add(watchedDir{repo: r.Path, dir: commonDir}) && ok
+		}
+		_ = filepath.WalkDir(filepath.Join(commonDir, "refs"), func(path string, d fs.DirEntry, err error) error {
+			if err == nil && d.IsDir() {
+				ok = iw.add(watchedDir{repo: r.Path, dir: path}) && ok
+			}
+			return nil
+		})
+	}
+
+	return iw.addTree(r.Path, r.Path) && ok
+}
+
+// addTree watches a working tree directory and its subdirectories,
+// skipping .git and ignored directories
+func (iw *inotifyWatcher) addTree(repo, root string) bool {
+	ok := true
+	_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return nil
+		}
+		if path != root && (d.Name() == ".git" || iw.skip(d.Name())) {
+			return filepath.SkipDir
+		}
+		// A nested repo has a watcher of its own
+		if path != root {
+			if _, err := os.Lstat(filepath.Join(path, ".git")); err == nil {
+				return filepath.SkipDir
+			}
+		}
+		if !iw.add(watchedDir{repo: repo, dir: path, worktree: true}) {
+			ok = false
+			return filepath.SkipAll
+		}
+		return nil
+	})
+	return ok
+}
+
+// add registers a single directory with inotify
+func (iw *inotifyWatcher) add(wd watchedDir) bool {
+	desc, err := unix.InotifyAddWatch(iw.fd, wd.dir, inotifyMask)
+	if err != nil {
+		return false
+	}
+	iw.mu.Lock()
+	iw.dirs[desc] = wd
+	iw.mu.Unlock()
+	return true
+}
+
+// readLoop turns inotify events into repo change notifications until the
+// inotify file is closed
+func (iw *inotifyWatcher) readLoop(w *Watcher) {
+	buf := make([]byte, 64*1024)
+	for {
+		n, err := iw.file.Read(buf)
+		if err != nil {
+			return
+		}
+
+		for offset := 0; offset+unix.SizeofInotifyEvent <= n; {
+			ev := (*unix.InotifyEvent)(unsafe.Pointer(&buf[offset]))
+			nameBytes := buf[offset+unix.SizeofInotifyEvent : offset+unix.SizeofInotifyEvent+int(ev.Len)]
+			offset += unix.SizeofInotifyEvent + int(ev.Len)
+
+			if ev.Mask&unix.IN_Q_OVERFLOW != 0 {
+				// Events were lost: every repo may have changed
+				iw.notifyAll(w)
+				continue
+			}
+
+			iw.mu.Lock()
+			wd, known := iw.dirs[int(ev.Wd)]
+			if ev.Mask&unix.IN_IGNORED != 0 {
+				delete(iw.dirs, int(ev.Wd))
+			}
+			iw.mu.Unlock()
+			if !known {
+				continue
+			}
+
+			// Start watching directories created inside a working tree
+			if wd.worktree && ev.Mask&unix.IN_ISDIR != 0 && ev.Mask&(unix.IN_CREATE|unix.IN_MOVED_TO) != 0 {
+				name := cString(nameBytes)
+				if name != ".git" && !iw.skip(name) {
+					iw.addTree(wd.repo, filepath.Join(wd.dir, name))
+				}
+			}
+
+			w.notify(wd.repo)
+		}
+	}
+}
+
+// notifyAll reports a change to every watched repo
+func (iw *inotifyWatcher) notifyAll(w *Watcher) {
+	iw.mu.Lock()
+	repos := make(map[string]bool)
+	for _, wd := range iw.dirs {
+		repos[wd.repo] = true
+	}
+	iw.mu.Unlock()
+
+	for repo := range repos {
+		w.notify(repo)
+	}
+}
+
+// cString converts a NUL-padded inotify name to a string
+func cString(b []byte) string {
+	for i, c := range b {
+		if c == 0 {
+			return string(b[:i])
+		}
+	}
+	return string(b)
+}
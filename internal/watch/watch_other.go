@@ -0,0 +1,10 @@
+//go:build !linux
+
+package watch
+
+import "github.com/Bharath-code/git-scope/internal/model"
+
+// startNative has no native backend on this platform; every repo is polled
+func startNative(w *Watcher, repos []model.Repo, skip func(name string) bool) ([]model.Repo, func() error, error) {
+	return repos, nil, nil
+}